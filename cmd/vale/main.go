@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"os/signal"
 
 	"github.com/errata-ai/vale/v2/internal/cli"
 	"github.com/errata-ai/vale/v2/internal/core"
@@ -109,9 +111,13 @@ func main() {
 	// NOTE: we need to delay checking the error because some command don't
 	// require a config file.
 	if argc > 0 {
+		// NOTE: A command that takes no arguments (e.g., `ls-rules`) only
+		// runs if there isn't a file of the same name to lint.
 		cmd, exists := cli.Actions[args[0]]
-		if exists && len(args[1:]) > 1 {
-			if err = cmd(args[1:], config); err != nil {
+		if exists && (len(args[1:]) > 1 || (argc == 1 && looksLikeStdin(args[0]))) {
+			if err != nil && cli.NeedsConfig[args[0]] {
+				handleError(err)
+			} else if err = cmd(args[1:], config); err != nil {
 				log.Fatal(err)
 			}
 			os.Exit(0)
@@ -127,7 +133,12 @@ func main() {
 		handleError(err)
 	}
 
-	linted, err := doLint(args, linter, cli.Flags.Glob)
+	// NOTE: This allows long-running rules (e.g., LanguageTool) to be
+	// interrupted.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	linted, err := doLint(args, linter.WithContext(ctx), cli.Flags.Glob)
 	if err != nil {
 		handleError(err)
 	}
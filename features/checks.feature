@@ -32,6 +32,28 @@ Feature: Checks
       test.md:16:1:Vale.Spelling:Did you really mean 'gitlab'?
       """
 
+  Scenario: LanguageTool
+    When I test LanguageTool "checks/LanguageTool"
+    Then the output should contain exactly:
+      """
+      test.md:1:3:LanguageTool.THEIR_IS:Did you mean 'There'?
+      test.md:3:1:LanguageTool.THEIR_IS:Did you mean 'There'?
+      test.md:6:3:LanguageTool.THEIR_IS:Did you mean 'There'?
+      test.md:10:3:LanguageTool.THEIR_IS:Did you mean 'There'?
+      """
+
+  Scenario: Structure
+    When I test "checks/Structure"
+    Then the output should contain exactly:
+      """
+      test.md:1:1:Test.Structure:Outline: missing required section 'Next steps'.
+      test.md:1:3:Test.Structure:Outline: the document must start with a paragraph, not a heading.
+      test.md:3:4:Test.Structure:Outline: 'Steps' is immediately followed by another heading.
+      test.md:5:5:Test.Structure:Outline: 'Configure' is immediately followed by another heading.
+      test.md:7:7:Test.Structure:Outline: heading level skips from 3 to 5.
+      test.md:11:4:Test.Structure:Outline: 'Prerequisites' must come before 'Steps'.
+      """
+
   Scenario: Sequence
     When I test "checks/Sequence"
     Then the output should contain exactly:
@@ -51,3 +73,79 @@ Feature: Checks
       test.md:17:42:LanguageTool.OF_ALL_TIMES:In this context, the idiom needs to be spelled 'of all time'.
       test.md:21:5:LanguageTool.APOS_ARE:Did you mean "endpoints" instead of "endpoint's"?
       """
+
+  Scenario: Duplicate
+    When I test "checks/Duplicate"
+    Then the output should contain exactly:
+      """
+      a.md:1:1:Test.Duplicate:This sentence is similar to the one on line 5 (88%).
+      a.md:5:1:Test.Duplicate:This sentence is similar to the one on line 1 (88%).
+      """
+
+  Scenario: Duplicate (project)
+    When I test "checks/DuplicateProject"
+    Then the output should contain exactly:
+      """
+      a.md:1:1:Test.Duplicate:This sentence is similar to the one on line 5 (88%).
+      b.md:1:1:Test.Duplicate:This sentence is similar to the one on c.md:1 (92%).
+      c.md:1:1:Test.Duplicate:This sentence is similar to the one on b.md:1 (92%).
+      """
+
+  Scenario: Escalate
+    When I test with "--output=../../templates/tmpl/line.tmpl" in "checks/Escalate"
+    Then the output should contain exactly:
+      """
+      test.md:1:4:Test.Passive:suggestion:'was said' is passive.
+      test.md:1:17:Test.Passive:suggestion:'was done' is passive.
+      test.md:1:30:Test.Passive:warning:'was seen' is passive.
+      test.md:1:43:Test.Passive:error:'was read' is passive.
+      test.md:1:56:Test.Passive:error:'was won' is passive.
+      """
+
+  Scenario: Escalate (minAlertLevel)
+    When I test with "--output=../../templates/tmpl/line.tmpl --minAlertLevel=warning" in "checks/Escalate"
+    Then the output should contain exactly:
+      """
+      test.md:1:30:Test.Passive:warning:'was seen' is passive.
+      test.md:1:43:Test.Passive:error:'was read' is passive.
+      test.md:1:56:Test.Passive:error:'was won' is passive.
+      """
+
+  Scenario: SuggestionBudget
+    When I test with "--output=../../templates/tmpl/line.tmpl" in "checks/Budget"
+    Then the output should contain exactly:
+      """
+      test.md:1:4:Test.Passive:suggestion:'was said' is passive.
+      test.md:1:17:Test.Passive:suggestion:'was done' is passive.
+      test.md:1:43:Test.Passive:warning:'was read' is passive.
+      test.md:1:56:Test.Passive:warning:'was won' is passive.
+      """
+
+  Scenario: Link
+    When I test "checks/Link"
+    Then the output should contain exactly:
+      """
+      index.md:3:45:Test.Links:'guide.html#setup' is broken.
+      index.md:4:20:Test.Links:'nowhere.md' is broken.
+      index.md:4:62:Test.Links:'#nope' is broken.
+      index.md:5:39:Test.Links:'https://other.org' is broken.
+      index.md:9:2:Test.Links:'setup.rst#install' is broken.
+      index.md:10:39:Test.Links:'manual.adoc#getting-started' is broken.
+      index.md:11:6:Test.Links:Unable to verify the anchor '#intro': 'notes.txt' has no known IDs.
+      manual.adoc:12:40:Test.Links:'#nope' is broken.
+      setup.rst:11:27:Test.Links:'#nope' is broken.
+      """
+
+  Scenario: Project
+    When I test "checks/Project"
+    Then the output should contain exactly:
+      """
+      b.md:1:15:Test.Spelling:Inconsistent spelling of 'colour'.
+      """
+
+  Scenario: LanguageTool (unreachable)
+    When I test "checks/LanguageToolFailure"
+    Then the output should contain:
+      """
+      test.md:1:1:LanguageTool.Grammar:The rule failed to run:
+      """
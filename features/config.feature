@@ -338,7 +338,7 @@ Feature: Config
 
   Scenario: Test a negated glob
     When I test glob "!*.py"
-    Then the output should not contain "py"
+    Then the output should not contain ".py:"
 
   Scenario: Test a negated glob directory
     When I test dir glob "!content/b/*"
@@ -352,8 +352,8 @@ Feature: Config
 
   Scenario: Test another negated glob
     When I test glob "!*.{md,py}"
-    Then the output should not contain "md"
-    And the output should not contain "py"
+    Then the output should not contain ".md:"
+    And the output should not contain ".py:"
 
   Scenario: Change a built-in rule's level
     Given a file named ".vale" with:
@@ -83,6 +83,49 @@ Feature: Lint
       """
     And the exit status should be 0
 
+  Scenario: Lint selected keys of a YAML file
+    When I lint "test.yml"
+    Then the output should contain exactly:
+      """
+      test.yml:3:12:vale.Annotations:'TODO' left in text
+      test.yml:6:5:vale.Annotations:'NOTE' left in text
+      test.yml:6:48:vale.Annotations:'XXX' left in text
+      test.yml:8:17:vale.Annotations:'FIXME' left in text
+      test.yml:13:21:vale.Annotations:'XXX' left in text
+      test.yml:17:29:vale.Annotations:'NOTE' left in text
+      """
+    And the exit status should be 0
+
+  Scenario: Lint a gettext catalog
+    When I lint "test.po"
+    Then the output should contain exactly:
+      """
+      test.po:11:24:vale.Annotations:'XXX' left in text
+      test.po:16:18:vale.Annotations:'NOTE' left in text
+      test.po:17:6:vale.Annotations:'FIXME' left in text
+      """
+    And the exit status should be 0
+
+  Scenario: Lint an XLIFF file
+    When I lint "test.xliff"
+    Then the output should contain exactly:
+      """
+      test.xliff:7:35:vale.Annotations:'XXX' left in text
+      test.xliff:12:39:vale.Annotations:'NOTE' left in text
+      """
+    And the exit status should be 0
+
+  Scenario: Lint a Git commit message
+    When I lint "COMMIT_EDITMSG"
+    Then the output should contain exactly:
+      """
+      COMMIT_EDITMSG:1:9:vale.Annotations:'XXX' left in text
+      COMMIT_EDITMSG:3:44:vale.Annotations:'NOTE' left in text
+      COMMIT_EDITMSG:4:13:vale.Annotations:'FIXME' left in text
+      COMMIT_EDITMSG:6:14:vale.Annotations:'TODO' left in text
+      """
+    And the exit status should be 0
+
   Scenario: Lint a Plain Text file
     When I lint "test.txt"
     Then the output should contain exactly:
@@ -189,6 +232,32 @@ Feature: Lint
       """
     And the exit status should be 0
 
+  Scenario: Lint a Jupyter notebook
+    When I lint "test.ipynb"
+    Then the output should contain exactly:
+      """
+      test.ipynb:9:6:vale.Annotations:'NOTE' left in text
+      test.ipynb:10:20:vale.Annotations:'FIXME' left in text
+      test.ipynb:27:8:vale.Annotations:'XXX' left in text
+      test.ipynb:35:14:vale.Annotations:'XXX' left in text
+      test.ipynb:48:11:vale.Annotations:'TODO' left in text
+      """
+    And the exit status should be 0
+
+  Scenario: Lint an Org-mode file
+    When I lint "test.org"
+    Then the output should contain exactly:
+      """
+      test.org:9:1:vale.Annotations:'NOTE' left in text
+      test.org:10:14:vale.Annotations:'FIXME' left in text
+      test.org:20:9:vale.Annotations:'XXX' left in text
+      test.org:22:12:vale.Annotations:'FIXME' left in text
+      test.org:24:3:vale.Annotations:'TODO' left in text
+      test.org:24:10:vale.Annotations:'XXX' left in text
+      test.org:36:9:vale.Annotations:'XXX' left in text
+      """
+    And the exit status should be 0
+
   Scenario: Lint a reStructuredText file
     When I lint "test.rst"
     Then the output should contain exactly:
@@ -206,6 +275,20 @@ Feature: Lint
       """
     And the exit status should be 0
 
+  Scenario: Lint a LaTeX file
+    When I lint "test.tex"
+    Then the output should contain exactly:
+      """
+      test.tex:12:1:vale.Annotations:'NOTE' left in text
+      test.tex:15:15:vale.Annotations:'TODO' left in text
+      test.tex:15:33:vale.Annotations:'FIXME' left in text
+      test.tex:26:15:vale.Annotations:'XXX' left in text
+      test.tex:27:9:vale.Annotations:'FIXME' left in text
+      test.tex:36:1:vale.Annotations:'TODO' left in text
+      test.tex:36:8:vale.Annotations:'XXX' left in text
+      """
+    And the exit status should be 0
+
   Scenario: Lint a Rust file
     When I lint "test.rs"
     Then the output should contain exactly:
@@ -84,3 +84,92 @@ Feature: Misc
       test.md:23:78:Vale.Spelling:Did you really mean 'config'?
       test.md:23:85:Vale.Spelling:Did you really mean 'json'?
       """
+
+  Scenario: Data keys
+    When I test "misc/keys"
+    Then the output should contain exactly:
+      """
+      api.yml:4:13:Test.Rule:Avoid 'TODO'.
+      api.yml:8:22:Test.Rule:Avoid 'TODO'.
+      app.toml:3:10:Test.Rule:Avoid 'TODO'.
+      strings.json:1:31:Test.Rule:Avoid 'TODO'.
+      """
+
+  Scenario: LaTeX macros
+    When I test "misc/latex"
+    Then the output should contain exactly:
+      """
+      test.tex:2:12:Test.Heading:Avoid 'TODO'.
+      test.tex:4:30:Test.Text:Avoid 'TODO'.
+      """
+
+  Scenario: MDX props
+    When I test "misc/mdx"
+    Then the output should contain exactly:
+      """
+      test.mdx:7:12:Test.Rule:Avoid 'TODO'.
+      test.mdx:9:7:Test.Rule:Avoid 'TODO'.
+      """
+
+  Scenario: XML scopes
+    When I test "misc/xml"
+    Then the output should contain exactly:
+      """
+      test.xml:2:15:Test.Heading:Avoid 'TODO'.
+      test.xml:2:15:Test.Text:Avoid 'TODO'.
+      test.xml:3:20:Test.Text:Avoid 'TODO'.
+      test.xml:6:17:Test.Text:Avoid 'TODO'.
+      """
+
+  Scenario: reStructuredText lines
+    When I test "misc/rst"
+    Then the output should contain exactly:
+      """
+      test.rst:5:11:Test.Rule:Avoid 'TODO'.
+      test.rst:7:9:Test.Rule:Avoid 'TODO'.
+      """
+
+  Scenario: Tags
+    When I test "misc/tags"
+    Then the output should contain exactly:
+      """
+      README.md:1:5:Other.Inclusive:Avoid 'whitelist'.
+      README.md:1:18:Test.Marketing:Avoid 'world-class'.
+      guide.md:1:5:Other.Inclusive:Avoid 'whitelist'.
+      """
+
+  Scenario: Notebook
+    When I test "misc/ipynb"
+    Then the output should contain exactly:
+      """
+      test.ipynb:6:16:Test.Structure:Outline: missing required section 'Prerequisites'.
+      test.ipynb:6:18:Test.Rule:Avoid 'TODO'.
+      test.ipynb:6:36:Test.Rule:Avoid 'TODO'.
+      test.ipynb:11:18:Test.Rule:Avoid 'TODO'.
+      test.ipynb:19:15:Test.Rule:Avoid 'TODO'.
+      test.ipynb:30:22:Test.Rule:Avoid 'TODO'.
+      test.ipynb:30:39:Test.Rule:Avoid 'TODO'.
+      """
+
+  Scenario: Localization
+    When I test "misc/l10n"
+    Then the output should contain exactly:
+      """
+      app.xlf:5:27:Test.English:Avoid 'file'.
+      fr.po:6:26:Test.French:Avoid 'file'.
+      fr.po:12:2:Test.French:Avoid 'file'.
+      """
+
+  Scenario: Lang
+    When I test "misc/lang"
+    Then the output should contain exactly:
+      """
+      guide.de.md:1:26:Test.Anglicism:Avoid 'Button'.
+      """
+
+  Scenario: Lang without a dictionary
+    When I test "misc/lang-eo"
+    Then the output should contain:
+      """
+      guide.md:1:1:Test.Spelling:The rule failed to run: no dictionary for 'eo'
+      """
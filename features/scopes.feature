@@ -109,3 +109,17 @@ Feature: Scopes
       test.rst:10:3:rules.List:'TODO' left in text
       test.rst:14:4:rules.List:'XXX' left in text
       """
+
+  Scenario: Commit
+    When I test scope "commit"
+    Then the output should contain exactly:
+      """
+      COMMIT_EDITMSG:1:1:rules.Subject:Subjects should be less than 50 characters.
+      COMMIT_EDITMSG:1:55:rules.SubjectPeriod:Don't end a subject with a period.
+      COMMIT_EDITMSG:3:55:rules.Body:'TODO' left in the body
+      COMMIT_EDITMSG:6:1:rules.Trailer:Use 'Signed-off-by' rather than 'Signed-Off-By'.
+      msg.commit:1:1:rules.Subject:Subjects should be less than 50 characters.
+      msg.commit:1:55:rules.SubjectPeriod:Don't end a subject with a period.
+      msg.commit:3:55:rules.Body:'TODO' left in the body
+      msg.commit:6:1:rules.Trailer:Use 'Signed-off-by' rather than 'Signed-Off-By'.
+      """
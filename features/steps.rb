@@ -1,4 +1,7 @@
+require 'json'
 require 'os'
+require 'socket'
+require 'uri'
 
 exe = 'vale'
 if OS.windows?
@@ -6,6 +9,35 @@ if OS.windows?
 end
 cmd = (exe + ' --output=line --sort --normalize --relative')
 
+# Start (once) a stand-in for LanguageTool's HTTP server on the port used by
+# `fixtures/checks/LanguageTool`. It flags every "Their is" that it's sent.
+def lt_stub
+  $lt_stub ||= Thread.new(TCPServer.new('127.0.0.1', 8910)) do |server|
+    loop do
+      client = server.accept
+
+      length = 0
+      while (line = client.gets) && line != "\r\n"
+        length = line.split(':', 2)[1].to_i if line =~ /^content-length:/i
+      end
+      text = URI.decode_www_form(client.read(length)).to_h.fetch('text', '')
+
+      matches = []
+      text.scan(/Their is/) do
+        matches << {
+          offset: Regexp.last_match.begin(0), length: 5,
+          replacements: [{ value: 'There' }], rule: { id: 'THEIR_IS' }
+        }
+      end
+
+      body = JSON.generate(matches: matches)
+      client.write("HTTP/1.1 200 OK\r\nContent-Type: application/json\r\n" \
+                   "Content-Length: #{body.bytesize}\r\nConnection: close\r\n\r\n#{body}")
+      client.close
+    end
+  end
+end
+
 Given(/^on Unix$/) do
   pending unless OS.posix?
 end
@@ -65,6 +97,12 @@ When(/^I test "(.*)"$/) do |dir|
   step %(I run `#{cmd} .`)
 end
 
+When(/^I test LanguageTool "(.*)"$/) do |dir|
+  lt_stub
+  step %(I cd to "../../fixtures/#{dir}")
+  step %(I run `#{cmd} .`)
+end
+
 When(/^I inspect "(.*)"$/) do |dir|
   step %(I cd to "../../fixtures/#{dir}")
   step %(I run `#{exe} .`)
@@ -156,3 +194,8 @@ When(/^I lint string "(.*)"$/) do |string|
     step %(I run `#{cmd} '#{string}'`)
   end
 end
+
+When(/^I test with "(.*)" in "(.*)"$/) do |flags, dir|
+  step %(I cd to "../../fixtures/#{dir}")
+  step %(I run `#{cmd} #{flags} .`)
+end
@@ -20,5 +20,6 @@ require (
 	github.com/xrash/smetrics v0.0.0-20201216005158-039620a65673
 	github.com/yuin/goldmark v1.3.2
 	golang.org/x/net v0.0.0-20201224014010-6772e930b67b
+	gopkg.in/neurosnap/sentences.v1 v1.0.6
 	gopkg.in/yaml.v2 v2.4.0
 )
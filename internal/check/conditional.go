@@ -1,10 +1,16 @@
 package check
 
 import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/errata-ai/vale/v2/internal/core"
 	"github.com/jdkato/regexp"
+	"github.com/karrick/godirwalk"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -19,9 +25,20 @@ type Conditional struct {
 	Second string
 	// `exceptions` (`array`): An array of strings to be ignored.
 	Exceptions []string
+	// `definitions` (`array`): A list of additional sources of definitions:
+	// glossary files (a Markdown definition list, a CSV file, or a plain
+	// list of terms), `Vocab` directories, or `$previous` (any file that
+	// precedes the current one in the configured `ReadingOrder`).
+	Definitions []string
 
 	exceptRe *regexp.Regexp
 	patterns []*regexp.Regexp
+
+	// defined holds the terms defined by glossaries, while ordered holds the
+	// terms defined by each file in the configured `ReadingOrder`.
+	defined map[string]struct{}
+	ordered []map[string]struct{}
+	order   []string
 }
 
 // NewConditional creates a new `conditional`-based rule.
@@ -54,11 +71,17 @@ func NewConditional(cfg *core.Config, generic baseCheck) (Conditional, error) {
 
 	// TODO: How do we support multiple patterns?
 	rule.patterns = expression
+
+	err = loadDefinitions(&rule, cfg)
+	if err != nil {
+		return rule, core.NewE201FromTarget(err.Error(), "definitions", path)
+	}
+
 	return rule, nil
 }
 
 // Run evalutes the given conditional statement.
-func (c Conditional) Run(txt string, f *core.File) []core.Alert {
+func (c Conditional) Run(ctx context.Context, txt string, f *core.File) ([]core.Alert, error) {
 	alerts := []core.Alert{}
 
 	// We first look for the consequent of the conditional statement.
@@ -79,17 +102,20 @@ func (c Conditional) Run(txt string, f *core.File) []core.Alert {
 	}
 
 	// Now we look for the antecedent.
-	locs := c.patterns[1].FindAllStringIndex(txt, -1)
+	locs := c.patterns[1].FindAllStringSubmatchIndex(txt, -1)
 	for _, loc := range locs {
 		s := txt[loc[0]:loc[1]]
-		if !core.StringInSlice(s, f.Sequences) && !isMatch(c.exceptRe, s) {
+		if !core.StringInSlice(s, f.Sequences) && !c.isDefined(s, f) && !isMatch(c.exceptRe, s) {
 			// If we've found one (e.g., "WHO") and we haven't marked it as
 			// being defined previously, send an Alert.
-			alerts = append(alerts, makeAlert(c.Definition, loc, txt))
+			lookup := re2Groups(
+				c.patterns[1], txt, loc, 0, c.patterns[1].NumSubexp())
+			alerts = append(alerts, makeGroupAlert(
+				c.Definition, loc[:2], txt, lookup))
 		}
 	}
 
-	return alerts
+	return alerts, nil
 }
 
 // Fields provides access to the internal rule definition.
@@ -101,3 +127,148 @@ func (c Conditional) Fields() Definition {
 func (c Conditional) Pattern() string {
 	return ""
 }
+
+// isDefined determines if `term` has been defined outside of `f`.
+func (c Conditional) isDefined(term string, f *core.File) bool {
+	if _, found := c.defined[term]; found {
+		return true
+	} else if len(c.ordered) == 0 {
+		return false
+	}
+
+	// Files that aren't part of the reading order come after all of those
+	// that are.
+	abs, _ := filepath.Abs(f.Path)
+	for i, defined := range c.ordered {
+		if c.order[i] == abs {
+			break
+		} else if _, found := defined[term]; found {
+			return true
+		}
+	}
+
+	return false
+}
+
+func loadDefinitions(c *Conditional, cfg *core.Config) error {
+	c.defined = make(map[string]struct{})
+	for _, source := range c.Definitions {
+		if source == "$previous" {
+			for _, fp := range cfg.ReadingOrder {
+				b, err := ioutil.ReadFile(fp)
+				if err != nil {
+					return err
+				}
+				defined := make(map[string]struct{})
+				for _, term := range c.findDefinitions(string(b)) {
+					defined[term] = struct{}{}
+				}
+				abs, _ := filepath.Abs(fp)
+				c.order = append(c.order, abs)
+				c.ordered = append(c.ordered, defined)
+			}
+			continue
+		}
+
+		path := core.FindAsset(cfg, source)
+		if !core.FileExists(path) {
+			return fmt.Errorf("the source '%s' does not exist", source)
+		}
+
+		terms, err := readGlossary(path)
+		if err != nil {
+			return err
+		}
+		for _, term := range terms {
+			c.defined[term] = struct{}{}
+		}
+
+		if !core.IsDir(path) {
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			for _, term := range c.findDefinitions(string(b)) {
+				c.defined[term] = struct{}{}
+			}
+		}
+	}
+	return nil
+}
+
+// findDefinitions returns all of the terms that `txt` defines according to
+// the rule's consequent (`second`).
+func (c Conditional) findDefinitions(txt string) []string {
+	terms := []string{}
+	for _, mat := range c.patterns[0].FindAllStringSubmatch(txt, -1) {
+		if len(mat) > 1 {
+			terms = append(terms, mat[1])
+		}
+	}
+	return terms
+}
+
+// readGlossary extracts the terms from a glossary file or `Vocab` directory.
+func readGlossary(path string) ([]string, error) {
+	terms := []string{}
+
+	if core.IsDir(path) {
+		err := godirwalk.Walk(path, &godirwalk.Options{
+			Callback: func(fp string, de *godirwalk.Dirent) error {
+				if de.Name() != "accept.txt" {
+					return nil
+				}
+				found, err := readGlossary(fp)
+				terms = append(terms, found...)
+				return err
+			},
+			Unsorted:            true,
+			AllowNonDirectory:   true,
+			FollowSymbolicLinks: true,
+		})
+		return terms, err
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return terms, err
+	}
+	content := core.Sanitize(string(b))
+
+	switch filepath.Ext(path) {
+	case ".csv":
+		records, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+		if err != nil {
+			return terms, err
+		}
+		for _, record := range records {
+			if len(record) > 0 && strings.TrimSpace(record[0]) != "" {
+				terms = append(terms, strings.TrimSpace(record[0]))
+			}
+		}
+	case ".md", ".markdown":
+		// We look for definition lists of the form
+		//
+		//    Term
+		//    : Definition
+		prev := ""
+		for _, line := range strings.Split(content, "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, ":") && prev != "" {
+				terms = append(terms, prev)
+				prev = ""
+			} else if !strings.HasPrefix(line, ":") {
+				prev = line
+			}
+		}
+	default:
+		for _, line := range strings.Split(content, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				terms = append(terms, line)
+			}
+		}
+	}
+
+	return terms, nil
+}
@@ -0,0 +1,78 @@
+package check
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+func TestConditionalDefinitions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vale-definitions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	sources := map[string]string{
+		"glossary.md":  "# Glossary\n\nAPI\n: Application Programming Interface\n",
+		"terms.csv":    "CLI,Command-line interface\n",
+		"intro.md":     "The World Health Organization (WHO) is ...\n",
+		"chapter.md":   "The WHO and the NASA.\n",
+		"unrelated.md": "Nothing here.\n",
+	}
+	for name, text := range sources {
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(text), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.StylesPath = dir
+	cfg.ReadingOrder = []string{
+		filepath.Join(dir, "intro.md"), filepath.Join(dir, "chapter.md")}
+
+	rule, err := NewConditional(cfg, baseCheck{
+		"path":        "",
+		"message":     "'%s' has no definition.",
+		"first":       `\b[A-Z]{3,5}\b`,
+		"second":      `(?:\b[A-Z][a-z]+ )+\(([A-Z]{3,5})\)`,
+		"definitions": []string{"glossary.md", "terms.csv", "$previous"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chapter, err := core.NewFile(filepath.Join(dir, "chapter.md"), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts, err := rule.Run(context.Background(), "The API, CLI, WHO and NASA.", chapter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alerts) != 1 || alerts[0].Match != "NASA" {
+		t.Errorf("expected one alert for 'NASA', got %v", alerts)
+	}
+
+	intro, err := core.NewFile(filepath.Join(dir, "intro.md"), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts, err = rule.Run(context.Background(), "The WHO.", intro)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alerts) != 1 {
+		t.Errorf("expected one alert for 'WHO', got %v", alerts)
+	}
+}
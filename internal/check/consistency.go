@@ -1,6 +1,7 @@
 package check
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -25,7 +26,9 @@ type Consistency struct {
 	// may appear.
 	Either map[string]string
 
-	steps []step
+	name    string // the rule's (style-qualified) name
+	steps   []step
+	project bool
 }
 
 // NewConsistency creates a new `consistency`-based rule.
@@ -47,6 +50,15 @@ func NewConsistency(cfg *core.Config, generic baseCheck) (Consistency, error) {
 		func() bool { return !rule.Nonword },
 		func() string { return "" }, true)
 
+	if core.StringInSlice("project", rule.Scope) {
+		// NOTE: Project-scoped rules look at all text, but their alerts are
+		// only reported once every file has been linted.
+		//
+		// See `Linter.lintProject`.
+		rule.project = true
+		rule.Scope = []string{"text"}
+	}
+
 	chkKey := strings.Split(name, ".")[1]
 	count := 0
 	for v1, v2 := range rule.Either {
@@ -62,7 +74,7 @@ func NewConsistency(cfg *core.Config, generic baseCheck) (Consistency, error) {
 			return rule, core.NewE201FromPosition(err.Error(), path, 1)
 		}
 
-		rule.Extends = name
+		rule.name = name
 		rule.Name = fmt.Sprintf("%s.%s", name, v1)
 		rule.steps = append(rule.steps, step{pattern: re, subs: subs})
 	}
@@ -71,10 +83,14 @@ func NewConsistency(cfg *core.Config, generic baseCheck) (Consistency, error) {
 }
 
 // Run looks for inconsistent use of a user-defined regex.
-func (o Consistency) Run(txt string, f *core.File) []core.Alert {
+func (o Consistency) Run(ctx context.Context, txt string, f *core.File) ([]core.Alert, error) {
 	alerts := []core.Alert{}
 	loc := []int{}
 
+	if o.project {
+		return o.runProject(txt), nil
+	}
+
 	for _, s := range o.steps {
 		matches := s.pattern.FindAllStringSubmatchIndex(txt, -1)
 		for _, submat := range matches {
@@ -87,12 +103,12 @@ func (o Consistency) Run(txt string, f *core.File) []core.Alert {
 		}
 
 		if matches != nil && core.AllStringsInSlice(s.subs, f.Sequences) {
-			o.Name = o.Extends
+			o.Name = o.name
 			alerts = append(alerts, makeAlert(o.Definition, loc, txt))
 		}
 	}
 
-	return alerts
+	return alerts, nil
 }
 
 // Fields provides access to the internal rule definition.
@@ -104,3 +120,26 @@ func (o Consistency) Fields() Definition {
 func (o Consistency) Pattern() string {
 	return ""
 }
+
+// runProject reports every occurrence of every option, leaving it to the
+// linter to decide which of them are in the minority across all files.
+func (o Consistency) runProject(txt string) []core.Alert {
+	alerts := []core.Alert{}
+
+	o.Name = o.name
+	for _, s := range o.steps {
+		names := s.pattern.SubexpNames()
+		for _, submat := range s.pattern.FindAllStringSubmatchIndex(txt, -1) {
+			for idx, mat := range submat {
+				if mat != -1 && idx > 0 && idx%2 == 0 && core.StringInSlice(names[idx/2], s.subs) {
+					a := makeAlert(o.Definition, []int{mat, submat[idx+1]}, txt)
+					a.Group = s.subs[0]
+					a.Variant = names[idx/2]
+					alerts = append(alerts, a)
+				}
+			}
+		}
+	}
+
+	return alerts
+}
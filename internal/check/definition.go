@@ -1,6 +1,7 @@
 package check
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strconv"
@@ -12,8 +13,11 @@ import (
 )
 
 // Rule represents in individual writing construct to enforce.
+//
+// `Run` reports an error if the rule couldn't be applied to `text` (e.g., a
+// network request failed), and should stop early if `ctx` is canceled.
 type Rule interface {
-	Run(text string, file *core.File) []core.Alert
+	Run(ctx context.Context, text string, file *core.File) ([]core.Alert, error)
 	Fields() Definition
 	Pattern() string
 }
@@ -22,7 +26,9 @@ type Rule interface {
 type Definition struct {
 	Action      core.Action
 	Description string
+	Escalate    map[string]int
 	Extends     string
+	Lang        string
 	Level       string
 	Limit       int
 	Link        string
@@ -30,6 +36,7 @@ type Definition struct {
 	Name        string
 	Scope       []string
 	Selector    core.Selector
+	Tags        []string
 }
 
 var defaultStyles = []string{"Vale"}
@@ -37,13 +44,39 @@ var extensionPoints = []string{
 	"capitalization",
 	"conditional",
 	"consistency",
+	"duplicate",
 	"existence",
+	"grammar",
+	"link",
 	"occurrence",
 	"repetition",
 	"substitution",
 	"readability",
 	"spelling",
 	"sequence",
+	"structure",
+}
+
+// knownScopes are the base names of the scopes that a rule may target.
+var knownScopes = []string{
+	"alt",
+	"attr",
+	"blockquote",
+	"code",
+	"comment",
+	"emphasis",
+	"heading",
+	"link",
+	"list",
+	"paragraph",
+	"project",
+	"raw",
+	"sentence",
+	"strong",
+	"summary",
+	"table",
+	"text",
+	"title",
 }
 var defaultRules = map[string]map[string]interface{}{
 	"Avoid": {
@@ -108,6 +141,14 @@ func buildRule(cfg *core.Config, generic baseCheck) (Rule, error) {
 		return NewConsistency(cfg, generic)
 	case "sequence":
 		return NewSequence(cfg, generic)
+	case "structure":
+		return NewStructure(cfg, generic)
+	case "link":
+		return NewLink(cfg, generic)
+	case "duplicate":
+		return NewDuplicate(cfg, generic)
+	case "grammar":
+		return NewGrammar(cfg, generic)
 	case "lt":
 		return NewLanguageTool(cfg, generic)
 	default:
@@ -132,6 +173,14 @@ func makeAlert(chk Definition, loc []int, txt string) core.Alert {
 	return a
 }
 
+// makeGroupAlert is like `makeAlert`, but it also expands any capture-group
+// references (`$1`, `${name}`) in the rule's message and description.
+func makeGroupAlert(chk Definition, loc []int, txt string, lookup groupLookup) core.Alert {
+	chk.Message = expandGroups(chk.Message, escapeLookup(lookup))
+	chk.Description = expandGroups(chk.Description, escapeLookup(lookup))
+	return makeAlert(chk, loc, txt)
+}
+
 func parse(file []byte, path string) (map[string]interface{}, error) {
 	generic := map[string]interface{}{}
 
@@ -158,6 +207,11 @@ func validateDefinition(generic map[string]interface{}, path string) error {
 			"Missing the required 'extends' key.",
 			path,
 			1)
+	} else if isRuleName(point) {
+		// The rule extends another rule (e.g., `extends: Microsoft.Terms`),
+		// so the remaining keys are checked once it's been merged with its
+		// parent (see `Manager.inherit`).
+		return nil
 	} else if !core.StringInSlice(point.(string), extensionPoints) {
 		key := point.(string)
 		return core.NewE201FromTarget(
@@ -182,6 +236,21 @@ func validateDefinition(generic map[string]interface{}, path string) error {
 		}
 	}
 
+	if escalate, ok := toStringMap(generic["escalate"]); ok {
+		for level := range escalate {
+			if !core.StringInSlice(level, core.AlertLevels) {
+				return core.NewE201FromTarget(
+					fmt.Sprintf("'escalate' levels must be one of %v", core.AlertLevels),
+					level,
+					path)
+			}
+		}
+	}
+
+	if err := validateScope(generic["scope"], path); err != nil {
+		return err
+	}
+
 	if generic["code"] != nil && generic["code"].(bool) {
 		return core.NewE201FromTarget(
 			"`code` is deprecated; please use `scope: raw` instead.",
@@ -192,6 +261,157 @@ func validateDefinition(generic map[string]interface{}, path string) error {
 	return nil
 }
 
+// validateScope ensures that every selector used in a rule's `scope` targets
+// a known scope.
+func validateScope(scope interface{}, path string) error {
+	var entries []string
+	switch v := scope.(type) {
+	case string:
+		entries = []string{v}
+	case []interface{}:
+		for _, entry := range v {
+			entries = append(entries, fmt.Sprintf("%v", entry))
+		}
+	case []string:
+		entries = v
+	}
+
+	for _, entry := range entries {
+		for _, term := range core.ScopeTerms(entry) {
+			base := strings.Split(term, ".")[0]
+			if !core.StringInSlice(base, knownScopes) {
+				return core.NewE201FromTarget(
+					fmt.Sprintf("'%s' is not a known scope; must be one of %v.",
+						term, knownScopes),
+					"scope",
+					path)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isRuleName reports whether `point` names another rule ("Style.Rule")
+// rather than an extension point.
+func isRuleName(point interface{}) bool {
+	name, ok := point.(string)
+	return ok && strings.Count(name, ".") == 1 && !strings.HasPrefix(name, ".")
+}
+
+// mergeDefinitions merges a child rule's definition into its parent's.
+//
+// Scalars and lists in `child` replace those in `parent`, while maps are
+// merged key-by-key. This can be controlled on a per-key basis using the
+// special `appends` and `replaces` keys:
+//
+//	extends: Microsoft.Terms
+//	appends:
+//	  swap:
+//	    utilize: use
+//	replaces:
+//	  exceptions:
+//	    - Foo
+//
+// in which case lists are appended to and maps are replaced, respectively.
+func mergeDefinitions(parent, child map[string]interface{}, path string) (map[string]interface{}, error) {
+	merged := make(map[string]interface{})
+	for k, v := range parent {
+		merged[k] = v
+	}
+
+	for k, v := range child {
+		if k == "appends" || k == "replaces" {
+			continue
+		}
+		merged[k] = mergeValues(merged[k], v)
+	}
+
+	for _, op := range []string{"appends", "replaces"} {
+		value, ok := child[op]
+		if !ok || value == nil {
+			continue
+		}
+
+		keys, ok := toStringMap(value)
+		if !ok {
+			return merged, core.NewE201FromTarget(
+				fmt.Sprintf("'%s' must be a map of keys to values.", op),
+				op,
+				path)
+		}
+
+		for k, v := range keys {
+			if op == "replaces" {
+				merged[k] = v
+				continue
+			}
+
+			switch existing := merged[k].(type) {
+			case nil:
+				merged[k] = v
+			case []interface{}:
+				items, ok := v.([]interface{})
+				if !ok {
+					return merged, core.NewE201FromTarget(
+						fmt.Sprintf("'%s' must be a list.", k), k, path)
+				}
+				merged[k] = append(append([]interface{}{}, existing...), items...)
+			default:
+				if _, ok := toStringMap(existing); !ok {
+					return merged, core.NewE201FromTarget(
+						fmt.Sprintf("'%s' can't be appended to.", k), k, path)
+				} else if _, ok := toStringMap(v); !ok {
+					return merged, core.NewE201FromTarget(
+						fmt.Sprintf("'%s' must be a map.", k), k, path)
+				}
+				merged[k] = mergeValues(existing, v)
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+// mergeValues merges `child` into `parent` if they're both maps; otherwise,
+// `child` takes precedence.
+func mergeValues(parent, child interface{}) interface{} {
+	p, ok := toStringMap(parent)
+	if !ok {
+		return child
+	}
+	c, ok := toStringMap(child)
+	if !ok {
+		return child
+	}
+
+	merged := make(map[interface{}]interface{})
+	for k, v := range p {
+		merged[k] = v
+	}
+	for k, v := range c {
+		merged[k] = mergeValues(merged[k], v)
+	}
+
+	return merged
+}
+
+// toStringMap converts the map types produced by the YAML decoder into a
+// `map[string]interface{}`.
+func toStringMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			converted[fmt.Sprintf("%v", k)] = v
+		}
+		return converted, true
+	}
+	return nil, false
+}
+
 func readStructureError(err error, path string) error {
 	r := regexp.MustCompile(`\* '(.+)' (.+)`)
 	if r.MatchString(err.Error()) {
@@ -0,0 +1,146 @@
+package check
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/mitchellh/mapstructure"
+)
+
+// signatureSize is the number of hash functions used in a MinHash signature.
+const signatureSize = 64
+
+// minHashSeeds are the (a, b) parameters of our hash functions.
+var minHashSeeds = makeSeeds(signatureSize)
+
+// Duplicate reports sentences or paragraphs that are nearly identical to
+// another one in the same file (or, optionally, in any linted file).
+//
+// Each block is fingerprinted using a MinHash signature of its word
+// shingles. Since this requires seeing every block, the comparisons happen
+// once linting is complete (see `lint.lintDuplicates`).
+type Duplicate struct {
+	Definition `mapstructure:",squash"`
+	// `threshold` (`float`): The (estimated) Jaccard similarity at which two
+	// blocks are considered duplicates. Defaults to 0.8.
+	Threshold float64
+	// `shingle` (`int`): The number of words per shingle. Defaults to 3.
+	Shingle int
+	// `min` (`int`): Blocks with fewer words are ignored. Defaults to 8.
+	Min int
+	// `project` (`bool`): Compare blocks across all linted files.
+	Project bool
+}
+
+// NewDuplicate creates a new `duplicate`-based rule.
+func NewDuplicate(cfg *core.Config, generic baseCheck) (Duplicate, error) {
+	rule := Duplicate{Threshold: 0.8, Shingle: 3, Min: 8}
+	path := generic["path"].(string)
+
+	err := mapstructure.WeakDecode(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	if rule.Threshold <= 0 || rule.Threshold > 1 {
+		return rule, core.NewE201FromTarget(
+			"'threshold' must be in the range (0, 1].", "threshold", path)
+	} else if rule.Shingle < 1 {
+		return rule, core.NewE201FromTarget(
+			"'shingle' must be at least 1.", "shingle", path)
+	}
+
+	return rule, nil
+}
+
+// Run fingerprints the given block.
+//
+// The resulting alert is a placeholder: it's only reported if a similar
+// block is found, at which point its message is formatted with the other
+// block's location and their similarity.
+func (d Duplicate) Run(ctx context.Context, txt string, f *core.File) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	words := core.WordTokenizer.Tokenize(strings.ToLower(txt))
+	if len(words) < d.Min || len(words) < d.Shingle {
+		return alerts, nil
+	}
+
+	txt = strings.TrimSpace(txt)
+	alerts = append(alerts, core.Alert{
+		Check: d.Name, Severity: d.Level, Link: d.Link, Span: []int{0, len(txt)},
+		Match: txt, Action: d.Action, Message: d.Message,
+		Description: d.Description, Signature: minHash(words, d.Shingle)})
+
+	return alerts, nil
+}
+
+// Fields provides access to the internal rule definition.
+func (d Duplicate) Fields() Definition {
+	return d.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (d Duplicate) Pattern() string {
+	return ""
+}
+
+// Similarity estimates the Jaccard similarity of two MinHash signatures.
+func Similarity(a, b []uint64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	same := 0
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+
+	return float64(same) / float64(len(a))
+}
+
+func minHash(words []string, size int) []uint64 {
+	sig := make([]uint64, signatureSize)
+	for i := range sig {
+		sig[i] = ^uint64(0)
+	}
+
+	for i := 0; i+size <= len(words); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:i+size], " ")))
+		x := h.Sum64()
+
+		for j, seed := range minHashSeeds {
+			if v := seed[0]*x + seed[1]; v < sig[j] {
+				sig[j] = v
+			}
+		}
+	}
+
+	return sig
+}
+
+// makeSeeds generates `n` deterministic (odd multiplier, offset) pairs using
+// SplitMix64.
+func makeSeeds(n int) [][2]uint64 {
+	seeds := make([][2]uint64, n)
+
+	state := uint64(0x9E3779B97F4A7C15)
+	next := func() uint64 {
+		state += 0x9E3779B97F4A7C15
+		z := state
+		z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+		z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+		return z ^ (z >> 31)
+	}
+
+	for i := range seeds {
+		seeds[i] = [2]uint64{next() | 1, next()}
+	}
+
+	return seeds
+}
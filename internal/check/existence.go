@@ -1,11 +1,13 @@
 package check
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/dlclark/regexp2"
 	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/inflect"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -24,8 +26,12 @@ type Existence struct {
 	// `tokens` (`array`): A list of tokens to be transformed into a
 	// non-capturing group.
 	Tokens []string
+	// `inflect` (`bool`): Extends `tokens` to the plural, possessive, and
+	// verb forms of its (non-regex) entries.
+	Inflect bool
 
 	pattern *regexp2.Regexp
+	groups  []string
 }
 
 // NewExistence creates a new `Rule` that extends `Existence`.
@@ -42,6 +48,18 @@ func NewExistence(cfg *core.Config, generic baseCheck) (Existence, error) {
 		return rule, readStructureError(err, path)
 	}
 
+	if rule.Inflect {
+		tokens := []string{}
+		for _, token := range rule.Tokens {
+			if isLiteral(token) {
+				tokens = append(tokens, inflect.Expand(token)...)
+			} else {
+				tokens = append(tokens, token)
+			}
+		}
+		rule.Tokens = tokens
+	}
+
 	regex := makeRegexp(
 		cfg.WordTemplate,
 		rule.IgnoreCase,
@@ -55,6 +73,7 @@ func NewExistence(cfg *core.Config, generic baseCheck) (Existence, error) {
 		return rule, core.NewE201FromPosition(err.Error(), path, 1)
 	}
 	rule.pattern = re
+	rule.groups = captureOrder(regex)
 
 	return rule, nil
 }
@@ -64,7 +83,7 @@ func NewExistence(cfg *core.Config, generic baseCheck) (Existence, error) {
 // This is simplest of the available extension points: it looks for any matches
 // of its internal `pattern` (calculated from `NewExistence`) against the
 // provided text.
-func (e Existence) Run(text string, file *core.File) []core.Alert {
+func (e Existence) Run(ctx context.Context, text string, file *core.File) ([]core.Alert, error) {
 	alerts := []core.Alert{}
 
 	for _, m := range core.FindAllString(e.pattern, text) {
@@ -73,13 +92,16 @@ func (e Existence) Run(text string, file *core.File) []core.Alert {
 		a := core.Alert{Check: e.Name, Severity: e.Level, Span: loc,
 			Link: e.Link, Match: m.String(), Action: e.Action}
 
-		a.Message, a.Description = formatMessages(e.Message,
-			e.Description, m.String())
+		lookup := escapeLookup(netGroups(m, e.groups))
+		a.Message, a.Description = formatMessages(
+			expandGroups(e.Message, lookup),
+			expandGroups(e.Description, lookup),
+			m.String())
 
 		alerts = append(alerts, a)
 	}
 
-	return alerts
+	return alerts, nil
 }
 
 // Fields provides access to the internal rule definition.
@@ -1,6 +1,7 @@
 package check
 
 import (
+	"context"
 	"testing"
 
 	"github.com/errata-ai/vale/v2/internal/core"
@@ -24,10 +25,48 @@ func TestExistence(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	alerts := rule.Run("This is a test.", file)
+	alerts, err := rule.Run(context.Background(), "This is a test.", file)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if len(alerts) != 1 {
 		t.Errorf("expected one alert, not %v", alerts)
 	}
 
 }
+
+func TestExistenceGroups(t *testing.T) {
+	def := baseCheck{
+		"tokens":  []string{`(?<kind>version) (\d+)`},
+		"message": "Use 'v$2' instead of '${kind} $2'.",
+	}
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewExistence(cfg, def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts, err := rule.Run(context.Background(), "Install version 2 today.", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("expected one alert, not %v", alerts)
+	}
+
+	expected := "Use 'v2' instead of 'version 2'."
+	if alerts[0].Message != expected {
+		t.Errorf("expected = %q, got = %q", expected, alerts[0].Message)
+	}
+}
@@ -0,0 +1,107 @@
+package check
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/grammar"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Grammar applies LanguageTool-style (`grammar.xml`) pattern rules without
+// the need for a LanguageTool server.
+type Grammar struct {
+	Definition `mapstructure:",squash"`
+	// `rules` (`string`): The path to a LanguageTool-style XML rule file,
+	// relative to the rule's own directory or `StylesPath`.
+	Rules string
+	// `ids` (`array`): If given, only the rules with these IDs (or category
+	// IDs) are applied.
+	IDs []string
+
+	rules []*grammar.Rule
+	tags  bool
+}
+
+// NewGrammar creates a new `grammar`-based rule.
+func NewGrammar(cfg *core.Config, generic baseCheck) (Grammar, error) {
+	rule := Grammar{}
+	path := generic["path"].(string)
+
+	err := mapstructure.WeakDecode(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	source := filepath.Join(filepath.Dir(path), rule.Rules)
+	if !core.FileExists(source) {
+		source = core.FindAsset(cfg, rule.Rules)
+	}
+
+	rules, err := grammar.LoadFile(source)
+	if err != nil {
+		return rule, core.NewE201FromTarget(err.Error(), "rules", path)
+	}
+
+	for _, r := range rules {
+		if len(rule.IDs) == 0 || core.StringInSlice(r.ID, rule.IDs) ||
+			core.StringInSlice(r.Category, rule.IDs) {
+			rule.rules = append(rule.rules, r)
+		}
+	}
+	rule.tags = grammar.NeedsTags(rule.rules)
+
+	return rule, nil
+}
+
+// Run applies the rule's patterns to the given text.
+func (g Grammar) Run(ctx context.Context, txt string, f *core.File) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	tokens := grammar.Tokenize(txt)
+	if g.tags && len(tokens) > 0 {
+		words := make([]string, len(tokens))
+		for i, tok := range tokens {
+			words[i] = tok.Text
+		}
+		for i, tok := range core.Tag(words) {
+			if i < len(tokens) {
+				tokens[i].Tag = tok.Tag
+			}
+		}
+	}
+
+	for _, r := range g.rules {
+		if err := ctx.Err(); err != nil {
+			return alerts, err
+		}
+
+		for _, m := range r.Find(txt, tokens) {
+			a := core.Alert{Check: g.Name, Severity: g.Level, Link: g.Link,
+				Span: []int{m.Start, m.End}, Match: txt[m.Start:m.End],
+				Action: g.Action}
+
+			if len(m.Suggestions) > 0 {
+				a.Action = core.Action{Name: "replace", Params: m.Suggestions}
+			}
+
+			a.Message, a.Description = formatMessages(g.Message,
+				g.Description, m.Message, r.ID)
+
+			alerts = append(alerts, a)
+		}
+	}
+
+	return alerts, nil
+}
+
+// Fields provides access to the internal rule definition.
+func (g Grammar) Fields() Definition {
+	return g.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (g Grammar) Pattern() string {
+	return ""
+}
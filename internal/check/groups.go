@@ -0,0 +1,200 @@
+package check
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dlclark/regexp2"
+	"github.com/jdkato/regexp"
+)
+
+// A groupLookup resolves a capture-group reference -- e.g., "1" (from `$1`)
+// or "name" (from `${name}`) -- to the text that it matched.
+type groupLookup func(ref string) (string, bool)
+
+// expandGroups replaces `$1`, `${1}` and `${name}` references in `s` with
+// their associated capture-group text.
+//
+// References that can't be resolved are left as-is, which means that
+// existing messages like "costs $5" continue to work. In a message that does
+// reference a group, a literal `$` may be written as `$$`; otherwise, the
+// message is left untouched (so "costs $$5" is still "costs $$5").
+func expandGroups(s string, lookup groupLookup) string {
+	if lookup == nil || !hasGroupRefs(s, lookup) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		} else if s[i+1] == '$' {
+			b.WriteByte('$')
+			i++
+			continue
+		}
+
+		ref, size := groupRef(s, i)
+		if text, ok := lookup(ref); ok && ref != "" {
+			b.WriteString(text)
+			i += size - 1
+		} else {
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String()
+}
+
+// hasGroupRefs determines if `s` contains a reference that `lookup` can
+// resolve.
+func hasGroupRefs(s string, lookup groupLookup) bool {
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] != '$' {
+			continue
+		} else if s[i+1] == '$' {
+			i++
+			continue
+		}
+
+		ref, _ := groupRef(s, i)
+		if _, ok := lookup(ref); ok && ref != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// groupRef parses the reference that starts with the `$` at `s[i]`,
+// returning its name (or number) and length in bytes.
+func groupRef(s string, i int) (string, int) {
+	if s[i+1] == '{' {
+		if end := strings.IndexByte(s[i:], '}'); end > 2 {
+			return s[i+2 : i+end], end + 1
+		}
+		return "", 0
+	}
+
+	j := i + 1
+	for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+		j++
+	}
+	return s[i+1 : j], j - i
+}
+
+// escapeLookup ensures that captured text can be safely passed through
+// `formatMessages`.
+func escapeLookup(lookup groupLookup) groupLookup {
+	if lookup == nil {
+		return nil
+	}
+	return func(ref string) (string, bool) {
+		text, ok := lookup(ref)
+		return strings.Replace(text, "%", "%%", -1), ok
+	}
+}
+
+// re2Groups creates a lookup for a `jdkato/regexp` match.
+//
+// `first` and `last` limit the lookup to a range of groups, which allows
+// a pattern made of multiple alternations -- e.g., `(a(b))|(c(d))` -- to
+// number each of its alternatives from `$1`.
+func re2Groups(re *regexp.Regexp, txt string, submat []int, first, last int) groupLookup {
+	names := re.SubexpNames()
+	return func(ref string) (string, bool) {
+		idx := -1
+		if n, err := strconv.Atoi(ref); err == nil {
+			idx = first + n
+		} else {
+			for i := first + 1; i <= last && i < len(names); i++ {
+				if names[i] == ref {
+					idx = i
+					break
+				}
+			}
+		}
+
+		if idx < first || idx > last || 2*idx+1 >= len(submat) {
+			return "", false
+		} else if submat[2*idx] < 0 {
+			return "", true
+		}
+		return txt[submat[2*idx]:submat[2*idx+1]], true
+	}
+}
+
+// netGroups creates a lookup for a `regexp2` match.
+//
+// regexp2 numbers its named groups after all of its unnamed ones, so we use
+// `order` (see `captureOrder`) to ensure that `$N` refers to the Nth group
+// from the left -- just like it does for `jdkato/regexp`.
+func netGroups(m *regexp2.Match, order []string) groupLookup {
+	return func(ref string) (string, bool) {
+		var g *regexp2.Group
+
+		if n, err := strconv.Atoi(ref); err == nil {
+			if n == 0 {
+				return m.String(), true
+			} else if n > len(order) {
+				return "", false
+			} else if order[n-1] != "" {
+				g = m.GroupByName(order[n-1])
+			} else {
+				unnamed := 0
+				for _, name := range order[:n] {
+					if name == "" {
+						unnamed++
+					}
+				}
+				g = m.GroupByNumber(unnamed)
+			}
+		} else {
+			g = m.GroupByName(ref)
+		}
+
+		if g == nil {
+			return "", false
+		}
+		return g.String(), true
+	}
+}
+
+// captureOrder lists the capture groups of `pattern` from left to right,
+// using "" for unnamed groups.
+func captureOrder(pattern string) []string {
+	order := []string{}
+
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\':
+			i++
+		case inClass:
+			inClass = c != ']'
+		case c == '[':
+			inClass = true
+			if strings.HasPrefix(pattern[i+1:], "^]") {
+				i += 2
+			} else if strings.HasPrefix(pattern[i+1:], "]") {
+				i++
+			}
+		case c == '(' && strings.HasPrefix(pattern[i+1:], "?"):
+			rest := pattern[i+2:]
+			if strings.HasPrefix(rest, "P<") {
+				rest = rest[1:]
+			}
+			if len(rest) > 1 && (rest[0] == '<' || rest[0] == '\'') &&
+				rest[1] != '=' && rest[1] != '!' {
+				end := strings.IndexAny(rest[1:], ">'")
+				if end > 0 {
+					order = append(order, rest[1:end+1])
+				}
+			}
+		case c == '(':
+			order = append(order, "")
+		}
+	}
+
+	return order
+}
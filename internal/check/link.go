@@ -0,0 +1,104 @@
+package check
+
+import (
+	"context"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/jdkato/regexp"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Link checks that a document's links can be resolved without making any
+// network requests.
+type Link struct {
+	Definition `mapstructure:",squash"`
+	// `allow` (`array`): A list of patterns that external URLs must match. If
+	// empty, external URLs aren't checked.
+	Allow []string
+	// `noAnchors` (`bool`): Turns off checking that `#anchors` exist. An
+	// anchor in a file whose IDs can't be found (e.g., a plain-text file) is
+	// reported, as a suggestion, as unverifiable.
+	NoAnchors bool
+
+	allowed []*regexp.Regexp
+}
+
+// NewLink creates a new `link`-based rule.
+func NewLink(cfg *core.Config, generic baseCheck) (Link, error) {
+	rule := Link{}
+	path := generic["path"].(string)
+
+	err := mapstructure.WeakDecode(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	for _, pattern := range rule.Allow {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return rule, core.NewE201FromTarget(err.Error(), pattern, path)
+		}
+		rule.allowed = append(rule.allowed, re)
+	}
+
+	// NOTE: Links are collected (and resolved) as we walk the document, so
+	// this rule needs to run once we've seen all of them.
+	rule.Definition.Scope = []string{"summary"}
+	return rule, nil
+}
+
+// Run reports any of `f`'s links that are broken.
+func (l Link) Run(ctx context.Context, txt string, f *core.File) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	for _, link := range f.Links {
+		broken := link.Broken == "file" || (link.Broken == "anchor" && !l.NoAnchors)
+		if link.External && len(l.allowed) > 0 {
+			broken = !l.isAllowed(link.Href)
+		}
+
+		// NOTE: An anchor in another file can't be checked until that file
+		// has been linted, so we report it provisionally.
+		pending := link.Target != "" && !l.NoAnchors
+		if broken || pending {
+			match := link.Text
+			if match == "" {
+				match = link.Href
+			}
+
+			a := core.Alert{Check: l.Name, Severity: l.Level, Link: l.Link,
+				Span: []int{1, 1}, Match: match, Action: l.Action}
+			if link.Line > 0 {
+				a.Line, a.Span = link.Line, link.Span
+			}
+			a.Message, a.Description = formatMessages(l.Message,
+				l.Description, link.Href, link.Text)
+			if pending {
+				a.Target, a.Anchor = link.Target, link.Anchor
+			}
+
+			alerts = append(alerts, a)
+		}
+	}
+
+	return alerts, nil
+}
+
+// Fields provides access to the internal rule definition.
+func (l Link) Fields() Definition {
+	return l.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (l Link) Pattern() string {
+	return ""
+}
+
+func (l Link) isAllowed(href string) bool {
+	for _, re := range l.allowed {
+		if re.MatchString(href) {
+			return true
+		}
+	}
+	return false
+}
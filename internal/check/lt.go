@@ -1,6 +1,8 @@
 package check
 
 import (
+	"context"
+
 	"github.com/errata-ai/vale/v2/internal/core"
 	"github.com/errata-ai/vale/v2/internal/rule"
 	"github.com/mitchellh/mapstructure"
@@ -9,13 +11,17 @@ import (
 // LanguageTool connects to to an instance of LanguageTool's HTTP server.
 type LanguageTool struct {
 	Definition `mapstructure:",squash"`
+	// `language`, `motherTongue`, `enabledRules`, `disabledRules`,
+	// `enabledCategories`, `disabledCategories` and `retries` override the
+	// `LT*` settings in `.vale.ini`.
+	core.LTOptions `mapstructure:",squash"`
 
 	config *core.Config
 }
 
 // NewLanguageTool creates a new `LanguageTool`-based rule.
 func NewLanguageTool(cfg *core.Config, generic baseCheck) (LanguageTool, error) {
-	rule := LanguageTool{}
+	rule := LanguageTool{LTOptions: cfg.LTOptions}
 	path := generic["path"].(string)
 
 	err := mapstructure.WeakDecode(generic, &rule)
@@ -23,18 +29,21 @@ func NewLanguageTool(cfg *core.Config, generic baseCheck) (LanguageTool, error)
 		return rule, readStructureError(err, path)
 	}
 
+	// NOTE: We send entire documents to LanguageTool in a single request,
+	// which is made once the document's prose has been collected (see
+	// `Run`).
+	rule.Definition.Scope = []string{"summary"}
+
 	rule.config = cfg
 	return rule, nil
 }
 
-// Run sends the given text to an instance of LanguageTool.
-func (l LanguageTool) Run(text string, file *core.File) []core.Alert {
-	alerts, err := rule.CheckWithLT(text, file, l.config)
-	if err != nil {
-		// FIXME: don't panic here
-		panic(err)
-	}
-	return alerts
+// Run sends the file's prose to an instance of LanguageTool.
+//
+// Unlike its summary, the file's prose includes its headings, list items,
+// and table cells.
+func (l LanguageTool) Run(ctx context.Context, text string, file *core.File) ([]core.Alert, error) {
+	return rule.CheckWithLT(ctx, file.Prose.String(), file, l.config, l.LTOptions)
 }
 
 // Fields provides access to the internal rule definition.
@@ -44,6 +44,15 @@ func NewManager(config *core.Config) (*Manager, error) {
 		return &mgr, err
 	}
 
+	// ... and, if any tags have been enabled, every other style (since the
+	// tagged rules could belong to any of them).
+	if mgr.hasEnabledTags() {
+		err = mgr.loadStyles(mgr.listStyles())
+		if err != nil {
+			return &mgr, err
+		}
+	}
+
 	for _, chk := range mgr.Config.Checks {
 		// Load any remaining individual rules.
 		if !strings.Contains(chk, ".") {
@@ -68,6 +77,12 @@ func NewManager(config *core.Config) (*Manager, error) {
 // AddRule adds the given rule to the manager.
 func (mgr *Manager) AddRule(name string, rule Rule) error {
 	if _, found := mgr.rules[name]; !found {
+		for _, s := range rule.Fields().Scope {
+			for _, term := range core.ScopeTerms(s) {
+				base := strings.Split(term, ".")[0]
+				mgr.scopes[base] = struct{}{}
+			}
+		}
 		mgr.rules[name] = rule
 		return nil
 	}
@@ -133,6 +148,11 @@ func (mgr *Manager) addCheck(file []byte, chkName, path string) error {
 		return err
 	}
 
+	generic, err = mgr.inherit(generic, path, []string{chkName})
+	if err != nil {
+		return err
+	}
+
 	// Set default values, if necessary.
 	generic["name"] = chkName
 	generic["path"] = path
@@ -151,12 +171,88 @@ func (mgr *Manager) addCheck(file []byte, chkName, path string) error {
 		return err
 	}
 
-	for _, s := range rule.Fields().Scope {
-		base := strings.Split(s, ".")[0]
-		mgr.scopes[base] = struct{}{}
+	return mgr.AddRule(chkName, rule)
+}
+
+// inherit resolves a definition that extends another rule (e.g., `extends:
+// Microsoft.Terms`) by merging it with its parent's definition.
+//
+// Parents are read from their source files rather than from the rules we've
+// already loaded, so the result doesn't depend on load order (or on the
+// parent being enabled). `chain` holds the names of the rules we've seen so
+// far, which allows us to detect cycles.
+func (mgr *Manager) inherit(generic map[string]interface{}, path string, chain []string) (map[string]interface{}, error) {
+	point := generic["extends"]
+	if !isRuleName(point) {
+		return generic, nil
 	}
 
-	return mgr.AddRule(chkName, rule)
+	parent := point.(string)
+	if core.StringInSlice(parent, chain) {
+		return generic, core.NewE201FromTarget(
+			fmt.Sprintf("Inheritance cycle: %s.",
+				strings.Join(append(chain, parent), " -> ")),
+			"extends",
+			path)
+	}
+
+	source, parentPath, err := mgr.findRuleSource(parent)
+	if err != nil {
+		return generic, core.NewE100("inherit/ReadFile", err)
+	} else if source == nil {
+		return generic, core.NewE201FromTarget(
+			fmt.Sprintf("Unable to find the rule '%s'.", parent),
+			parent,
+			path)
+	}
+
+	base, err := parse(source, parentPath)
+	if err != nil {
+		return generic, err
+	}
+
+	base, err = mgr.inherit(base, parentPath, append(chain, parent))
+	if err != nil {
+		return generic, err
+	}
+
+	merged, err := mergeDefinitions(base, generic, path)
+	if err != nil {
+		return generic, err
+	}
+	merged["extends"] = base["extends"]
+
+	return merged, validateDefinition(merged, path)
+}
+
+// findRuleSource returns the YAML source of the rule `name` ("Style.Rule"),
+// which may be on one of our `Paths` or one of the built-in styles. The
+// source is nil if the rule doesn't exist.
+//
+// The paths are searched in the same order as `loadStyles` searches them, so
+// that a rule's parent comes from the same copy of its style that we'd load.
+func (mgr *Manager) findRuleSource(name string) ([]byte, string, error) {
+	parts := strings.Split(name, ".")
+
+	for _, baseDir := range mgr.Config.Paths {
+		if baseDir == "" {
+			continue
+		}
+		path := filepath.Join(baseDir, parts[0], parts[1]+".yml")
+		if core.FileExists(path) {
+			b, err := ioutil.ReadFile(path)
+			return b, path, err
+		}
+	}
+
+	if core.StringInSlice(parts[0], defaultStyles) {
+		b, err := rule.Asset(filepath.Join("rule", parts[0], parts[1]+".yml"))
+		if err == nil {
+			return b, "", nil
+		}
+	}
+
+	return nil, "", nil
 }
 
 func (mgr *Manager) loadDefaultRules() error {
@@ -259,6 +355,45 @@ func (mgr *Manager) loadVocabRules() {
 	}
 }
 
+func (mgr *Manager) hasEnabledTags() bool {
+	for _, on := range mgr.Config.GTags {
+		if on {
+			return true
+		}
+	}
+	for _, tags := range mgr.Config.STags {
+		for _, on := range tags {
+			if on {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// listStyles returns the names of all styles on our `StylesPath`.
+func (mgr *Manager) listStyles() []string {
+	styles := []string{}
+	for _, baseDir := range mgr.Config.Paths {
+		if baseDir == "" {
+			continue
+		}
+		entries, err := ioutil.ReadDir(baseDir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if core.IsDir(filepath.Join(baseDir, name)) && name != "Vocab" &&
+				!strings.HasPrefix(name, ".") &&
+				!core.StringInSlice(name, styles) {
+				styles = append(styles, name)
+			}
+		}
+	}
+	return styles
+}
+
 func (mgr *Manager) hasStyle(name string) bool {
 	styles := append(mgr.styles, defaultStyles...)
 	return core.StringInSlice(name, styles)
@@ -1,7 +1,14 @@
 package check
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
 )
 
 var checktests = []struct {
@@ -54,3 +61,162 @@ func TestFormatMessage(t *testing.T) {
 		}
 	}
 }
+
+func writeRule(t *testing.T, dir, style, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, style), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, style, name+".yml")
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInheritance(t *testing.T) {
+	dir := t.TempDir()
+
+	// The children are loaded before their parents.
+	writeRule(t, dir, "A", "Strict", `extends: B.Terms
+level: error
+swap:
+  leverage: use
+`)
+	writeRule(t, dir, "A", "Avoid", `extends: B.Avoid
+appends:
+  tokens:
+    - bar
+`)
+	writeRule(t, dir, "B", "Terms", `extends: C.Terms
+message: "Prefer '%s' over '%s'."
+`)
+	writeRule(t, dir, "B", "Avoid", `extends: existence
+message: "Avoid '%s'."
+tokens:
+  - foo
+`)
+	writeRule(t, dir, "C", "Terms", `extends: substitution
+message: "Use '%s' instead of '%s'."
+swap:
+  utilize: use
+`)
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.StylesPath, cfg.Paths = dir, []string{dir}
+
+	mgr, err := NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"Strict", "Avoid"} {
+		path := filepath.Join(dir, "A", name+".yml")
+		if err = mgr.addRuleFromSource(name+".yml", path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	terms, ok := mgr.Rules()["A.Strict"].(Substitution)
+	if !ok {
+		t.Fatalf("expected a substitution rule, got %T", mgr.Rules()["A.Strict"])
+	}
+
+	def := terms.Fields()
+	if def.Level != "error" || def.Message != "Prefer '%s' over '%s'." {
+		t.Errorf("unexpected definition: %+v", def)
+	}
+
+	expected := map[string]string{"utilize": "use", "leverage": "use"}
+	if !reflect.DeepEqual(terms.Swap, expected) {
+		t.Errorf("expected = %v, got = %v", expected, terms.Swap)
+	}
+
+	avoid, ok := mgr.Rules()["A.Avoid"].(Existence)
+	if !ok {
+		t.Fatalf("expected an existence rule, got %T", mgr.Rules()["A.Avoid"])
+	} else if !reflect.DeepEqual(avoid.Tokens, []string{"foo", "bar"}) {
+		t.Errorf("unexpected tokens: %v", avoid.Tokens)
+	}
+}
+
+func TestInheritanceFromPaths(t *testing.T) {
+	base, mock := t.TempDir(), t.TempDir()
+
+	// A parent that's only on the second path ...
+	writeRule(t, base, "A", "Avoid", "extends: B.Avoid\n")
+	writeRule(t, mock, "B", "Avoid", `extends: existence
+message: "Avoid '%s'."
+tokens:
+  - foo
+`)
+
+	// ... and one that's on both, in which case the first path takes
+	// precedence (as it does when loading styles).
+	writeRule(t, base, "A", "Other", "extends: C.Avoid\n")
+	writeRule(t, base, "C", "Avoid", "extends: existence\nmessage: bar\ntokens: [bar]\n")
+	writeRule(t, mock, "C", "Avoid", "extends: existence\nmessage: baz\ntokens: [baz]\n")
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.StylesPath, cfg.Paths = base, []string{base, mock}
+
+	mgr, err := NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string][]string{"Avoid": {"foo"}, "Other": {"bar"}}
+	for name, tokens := range expected {
+		path := filepath.Join(base, "A", name+".yml")
+		if err = mgr.addRuleFromSource(name+".yml", path); err != nil {
+			t.Fatal(err)
+		}
+
+		rule, ok := mgr.Rules()["A."+name].(Existence)
+		if !ok {
+			t.Fatalf("expected an existence rule, got %T", mgr.Rules()["A."+name])
+		} else if !reflect.DeepEqual(rule.Tokens, tokens) {
+			t.Errorf("A.%s: expected %v, got %v", name, tokens, rule.Tokens)
+		}
+	}
+}
+
+func TestInheritanceCycle(t *testing.T) {
+	dir := t.TempDir()
+
+	writeRule(t, dir, "A", "One", "extends: A.Two\n")
+	writeRule(t, dir, "A", "Two", "extends: A.One\n")
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.StylesPath, cfg.Paths = dir, []string{dir}
+
+	mgr, err := NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = mgr.addRuleFromSource("One.yml", filepath.Join(dir, "A", "One.yml"))
+	if err == nil || !strings.Contains(err.Error(), "A.One -> A.Two -> A.One") {
+		t.Errorf("expected a cycle error, got %v", err)
+	}
+}
+
+func TestUnknownScope(t *testing.T) {
+	rule := []byte("extends: existence\nmessage: foo\nscope: text & ~headings\n")
+	if _, err := parse(rule, "Unknown.yml"); err == nil {
+		t.Error("expected an error for an unknown scope")
+	}
+
+	rule = []byte("extends: existence\nmessage: foo\nscope: [text, ~heading]\n")
+	if _, err := parse(rule, "Known.yml"); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,6 +1,8 @@
 package check
 
 import (
+	"context"
+
 	"github.com/errata-ai/vale/v2/internal/core"
 	"github.com/jdkato/regexp"
 	"github.com/mitchellh/mapstructure"
@@ -50,23 +52,25 @@ func NewOccurrence(cfg *core.Config, generic baseCheck) (Occurrence, error) {
 
 // Run checks the number of occurrences of a user-defined regex against a
 // certain threshold.
-func (o Occurrence) Run(txt string, f *core.File) []core.Alert {
+func (o Occurrence) Run(ctx context.Context, txt string, f *core.File) ([]core.Alert, error) {
 	alerts := []core.Alert{}
 
-	locs := o.pattern.FindAllStringIndex(txt, -1)
+	locs := o.pattern.FindAllStringSubmatchIndex(txt, -1)
 	occurrences := len(locs)
 	if occurrences > o.Max || occurrences < o.Min {
 		// NOTE: We take only the first match (`locs[0]`) instead of the whole
 		// scope (`txt`) to avoid having to fall back to string matching.
 		//
 		// See (core/util.go#initialPosition).
-		a := makeAlert(o.Definition, locs[0], txt)
-		a.Message = o.Message
-		a.Description = o.Description
+		lookup := re2Groups(o.pattern, txt, locs[0], 0, o.pattern.NumSubexp())
+
+		a := makeAlert(o.Definition, locs[0][:2], txt)
+		a.Message = expandGroups(o.Message, lookup)
+		a.Description = expandGroups(o.Description, lookup)
 		alerts = append(alerts, a)
 	}
 
-	return alerts
+	return alerts, nil
 }
 
 // Fields provides access to the internal rule definition.
@@ -1,6 +1,7 @@
 package check
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/errata-ai/vale/v2/internal/core"
@@ -41,7 +42,7 @@ func NewReadability(cfg *core.Config, generic baseCheck) (Readability, error) {
 }
 
 // Run calculates the readability level of the given text.
-func (o Readability) Run(txt string, f *core.File) []core.Alert {
+func (o Readability) Run(ctx context.Context, txt string, f *core.File) ([]core.Alert, error) {
 	var grade float64
 	alerts := []core.Alert{}
 
@@ -71,7 +72,7 @@ func (o Readability) Run(txt string, f *core.File) []core.Alert {
 		alerts = append(alerts, a)
 	}
 
-	return alerts
+	return alerts, nil
 }
 
 // Fields provides access to the internal rule definition.
@@ -1,9 +1,12 @@
 package check
 
 import (
+	"context"
+	"fmt"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/errata-ai/vale/v2/internal/core"
 	"github.com/errata-ai/vale/v2/pkg/spell"
@@ -56,6 +59,21 @@ type Spelling struct {
 
 	exceptRe *regexp.Regexp
 	gs       *spell.Checker
+
+	// NOTE: Unless a rule specifies its own dictionaries, files written in
+	// a language other than English are checked against a dictionary named
+	// after that language (e.g., `de_DE` for "de-DE"), which we load on
+	// first use. A file whose language has no such dictionary is an error.
+	explicit bool
+	dicpath  string
+	vocab    []string
+	byLang   *langModels
+}
+
+// langModels caches the per-language dictionaries shared by a rule's copies.
+type langModels struct {
+	sync.Mutex
+	models map[string]*spell.Checker
 }
 
 func addFilters(s *Spelling, generic baseCheck, cfg *core.Config) error {
@@ -64,10 +82,12 @@ func addFilters(s *Spelling, generic baseCheck, cfg *core.Config) error {
 		//
 		// NOTE: This makes a big difference: ~50s -> ~13s.
 		for _, filter := range generic["filters"].([]interface{}) {
-			if pat, e := regexp.Compile(filter.(string)); e == nil {
-				// TODO: Should we report malformed patterns?
-				s.Filters = append(s.Filters, pat)
+			pat, e := regexp.Compile(filter.(string))
+			if e != nil {
+				return core.NewE201FromTarget(
+					e.Error(), filter.(string), generic["path"].(string))
 			}
+			s.Filters = append(s.Filters, pat)
 		}
 		delete(generic, "filters")
 	}
@@ -105,7 +125,9 @@ func NewSpelling(cfg *core.Config, generic baseCheck) (Spelling, error) {
 	path := generic["path"].(string)
 	name := generic["name"].(string)
 
-	addFilters(&rule, generic, cfg)
+	if err := addFilters(&rule, generic, cfg); err != nil {
+		return rule, err
+	}
 	addExceptions(&rule, generic, cfg)
 
 	err := mapstructure.WeakDecode(generic, &rule)
@@ -113,6 +135,17 @@ func NewSpelling(cfg *core.Config, generic baseCheck) (Spelling, error) {
 		return rule, readStructureError(err, path)
 	}
 
+	rule.explicit = rule.Aff != "" || rule.Dic != "" || len(rule.Dictionaries) > 0
+	rule.byLang = &langModels{models: make(map[string]*spell.Checker)}
+
+	rule.dicpath = cfg.DictionaryPath
+	if rule.Dicpath != "" {
+		rule.dicpath, err = filepath.Abs(rule.Dicpath)
+		if err != nil {
+			return rule, core.NewE201FromPosition(err.Error(), path, 1)
+		}
+	}
+
 	model, err = makeSpeller(&rule, cfg)
 	if err != nil {
 		return rule, core.NewE201FromPosition(err.Error(), path, 1)
@@ -134,6 +167,9 @@ func NewSpelling(cfg *core.Config, generic baseCheck) (Spelling, error) {
 			exists = model.AddWordListFile(vocab)
 			// TODO: check error?
 		}
+		if exists == nil {
+			rule.vocab = append(rule.vocab, vocab)
+		}
 	}
 
 	if !rule.Custom {
@@ -145,14 +181,27 @@ func NewSpelling(cfg *core.Config, generic baseCheck) (Spelling, error) {
 }
 
 // Run performs spell-checking on the provided text.
-func (s Spelling) Run(txt string, f *core.File) []core.Alert {
+func (s Spelling) Run(ctx context.Context, txt string, f *core.File) ([]core.Alert, error) {
 	alerts := []core.Alert{}
 
+	model := s.gs
+	if !s.explicit && f.Lang != "" && core.BaseLang(f.Lang) != "en" {
+		model = s.forLang(f.Lang)
+		if model == nil {
+			// NOTE: We report this (once per file) rather than skip the file
+			// silently, since it'd otherwise look like it has no errors.
+			name := strings.Replace(f.Lang, "-", "_", -1)
+			return alerts, fmt.Errorf(
+				"no dictionary for '%s' (e.g., '%s.dic' and '%s.aff') was found",
+				f.Lang, name, name)
+		}
+	}
+
 	// This ensures that we respect `.aff` entries like `ICONV ’ '`,
 	// allowing us to avoid false positives.
 	//
 	// See https://github.com/errata-ai/vale/v2/issues/148.
-	txt = s.gs.Convert(txt)
+	txt = model.Convert(txt)
 
 OUTER:
 	for _, word := range core.WordTokenizer.Tokenize(txt) {
@@ -162,7 +211,7 @@ OUTER:
 			}
 		}
 
-		if !s.gs.Spell(word) && !isMatch(s.exceptRe, word) {
+		if !model.Spell(word) && !isMatch(s.exceptRe, word) {
 			offset := strings.Index(txt, word)
 			loc := []int{offset, offset + len(word)}
 
@@ -176,7 +225,7 @@ OUTER:
 		}
 	}
 
-	return alerts
+	return alerts, nil
 }
 
 // Fields provides access to the internal rule definition.
@@ -189,6 +238,40 @@ func (s Spelling) Pattern() string {
 	return ""
 }
 
+// forLang returns the (cached) dictionary for `lang`, or nil if there isn't
+// one.
+func (s Spelling) forLang(lang string) *spell.Checker {
+	s.byLang.Lock()
+	defer s.byLang.Unlock()
+
+	if model, found := s.byLang.models[lang]; found {
+		return model
+	}
+
+	var model *spell.Checker
+	for _, name := range []string{strings.Replace(lang, "-", "_", -1), core.BaseLang(lang)} {
+		options := []spell.CheckerOption{spell.UsingDictionary(name)}
+		if s.dicpath != "" {
+			options = append(options, spell.WithPath(s.dicpath))
+		}
+
+		if m, err := spell.NewChecker(options...); err == nil {
+			model = m
+			break
+		}
+	}
+
+	if model != nil {
+		for _, vocab := range s.vocab {
+			// NOTE: These were already loaded successfully in `NewSpelling`.
+			model.AddWordListFile(vocab)
+		}
+	}
+
+	s.byLang.models[lang] = model
+	return model
+}
+
 func makeSpeller(s *Spelling, cfg *core.Config) (*spell.Checker, error) {
 	var options []spell.CheckerOption
 
@@ -196,12 +279,8 @@ func makeSpeller(s *Spelling, cfg *core.Config) (*spell.Checker, error) {
 	dicloc := core.FindAsset(cfg, s.Dic)
 
 	options = append(options, spell.WithDefault(s.Append))
-	if s.Dicpath != "" {
-		p, err := filepath.Abs(s.Dicpath)
-		if err != nil {
-			return nil, err
-		}
-		options = append(options, spell.WithPath(p))
+	if s.dicpath != "" {
+		options = append(options, spell.WithPath(s.dicpath))
 	}
 
 	if core.FileExists(affloc) && core.FileExists(dicloc) {
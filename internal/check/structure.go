@@ -0,0 +1,183 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/jdkato/regexp"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Structure enforces constraints on a document's outline -- that is, its
+// headings and the block-level content between them.
+//
+// The rule's `message` is formatted with a description of the broken
+// constraint (e.g., "missing required section 'Next steps'").
+type Structure struct {
+	Definition `mapstructure:",squash"`
+	// `ignorecase` (`bool`): Makes all heading matches case-insensitive.
+	Ignorecase bool
+	// `required` (`array`): A list of headings that must be present.
+	Required []string
+	// `order` (`array`): A list of headings that, if present, must appear in
+	// the given order.
+	Order []string
+	// `maxDepth` (`int`): The deepest heading level allowed (e.g., `3` for
+	// `<h3>`).
+	MaxDepth int
+	// `noSkip` (`bool`): Disallows skipping heading levels (e.g., `<h2>` ->
+	// `<h4>`).
+	NoSkip bool
+	// `noStacked` (`bool`): Disallows headings that are immediately followed
+	// by another heading.
+	NoStacked bool
+	// `first` (`string`): The block type (e.g., `paragraph`) that a document
+	// must start with.
+	First string
+
+	required []*regexp.Regexp
+	order    []*regexp.Regexp
+}
+
+// NewStructure creates a new `structure`-based rule.
+func NewStructure(cfg *core.Config, generic baseCheck) (Structure, error) {
+	rule := Structure{}
+	path := generic["path"].(string)
+
+	err := mapstructure.WeakDecode(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	rule.required, err = compileHeadings(rule.Required, rule.Ignorecase)
+	if err != nil {
+		return rule, core.NewE201FromPosition(err.Error(), path, 1)
+	}
+
+	rule.order, err = compileHeadings(rule.Order, rule.Ignorecase)
+	if err != nil {
+		return rule, core.NewE201FromPosition(err.Error(), path, 1)
+	}
+
+	// NOTE: Like `readability`, this rule needs to see the entire document.
+	rule.Definition.Scope = []string{"summary"}
+	return rule, nil
+}
+
+// Run checks the outline of `f` against the rule's constraints.
+func (s Structure) Run(ctx context.Context, txt string, f *core.File) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	headings := []core.Section{}
+	for _, sec := range f.Outline {
+		if sec.Level > 0 {
+			headings = append(headings, sec)
+		}
+	}
+
+	if s.First != "" && len(f.Outline) > 0 {
+		sec := f.Outline[0]
+		if sec.Level > 0 {
+			alerts = append(alerts, s.makeAlert(f, sec, fmt.Sprintf(
+				"the document must start with a %s, not a heading", s.First)))
+		} else if len(sec.Blocks) > 0 && sec.Blocks[0] != s.First {
+			alerts = append(alerts, s.makeAlert(f, sec, fmt.Sprintf(
+				"the document must start with a %s, not a %s", s.First, sec.Blocks[0])))
+		}
+	}
+
+	for i, re := range s.required {
+		if !anyHeadingMatches(re, headings) {
+			alerts = append(alerts, s.makeAlert(f, core.Section{}, fmt.Sprintf(
+				"missing required section '%s'", s.Required[i])))
+		}
+	}
+
+	highest, after := -1, ""
+	for i, sec := range headings {
+		if s.MaxDepth > 0 && sec.Level > s.MaxDepth {
+			alerts = append(alerts, s.makeAlert(f, sec, fmt.Sprintf(
+				"heading level %d is deeper than %d", sec.Level, s.MaxDepth)))
+		}
+
+		if s.NoSkip && i > 0 && sec.Level > headings[i-1].Level+1 {
+			alerts = append(alerts, s.makeAlert(f, sec, fmt.Sprintf(
+				"heading level skips from %d to %d", headings[i-1].Level, sec.Level)))
+		}
+
+		if s.NoStacked && len(sec.Blocks) == 0 && i+1 < len(headings) {
+			alerts = append(alerts, s.makeAlert(f, sec, fmt.Sprintf(
+				"'%s' is immediately followed by another heading", sec.Text)))
+		}
+
+		for idx, re := range s.order {
+			if re.MatchString(sec.Text) {
+				if idx < highest {
+					alerts = append(alerts, s.makeAlert(f, sec, fmt.Sprintf(
+						"'%s' must come before '%s'", sec.Text, after)))
+				} else {
+					highest, after = idx, sec.Text
+				}
+				break
+			}
+		}
+	}
+
+	return alerts, nil
+}
+
+// Fields provides access to the internal rule definition.
+func (s Structure) Fields() Definition {
+	return s.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (s Structure) Pattern() string {
+	return ""
+}
+
+// makeAlert reports that `sec` (or, if it has no line, the document) breaks
+// the constraint described by `problem`.
+func (s Structure) makeAlert(f *core.File, sec core.Section, problem string) core.Alert {
+	a := core.Alert{Check: s.Name, Severity: s.Level, Link: s.Link,
+		Line: 1, Span: []int{1, 1}, Match: sec.Text, Action: s.Action}
+
+	if sec.Line > 0 && sec.Line <= len(f.Lines) {
+		a.Line = sec.Line
+		if idx := strings.Index(f.Lines[sec.Line-1], sec.Text); idx >= 0 && sec.Text != "" {
+			col := utf8.RuneCountInString(f.Lines[sec.Line-1][:idx]) + 1
+			a.Span = []int{col, col + utf8.RuneCountInString(sec.Text) - 1}
+		}
+	}
+
+	a.Message, a.Description = formatMessages(s.Message, s.Description, problem)
+	return a
+}
+
+func compileHeadings(headings []string, ignorecase bool) ([]*regexp.Regexp, error) {
+	patterns := []*regexp.Regexp{}
+	for _, h := range headings {
+		regex := fmt.Sprintf(tokenTemplate, h)
+		if ignorecase {
+			regex = ignoreCase + regex
+		}
+		re, err := regexp.Compile(regex)
+		if err != nil {
+			return patterns, err
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, nil
+}
+
+func anyHeadingMatches(re *regexp.Regexp, headings []core.Section) bool {
+	for _, h := range headings {
+		if re.MatchString(h.Text) {
+			return true
+		}
+	}
+	return false
+}
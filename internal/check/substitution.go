@@ -1,10 +1,13 @@
 package check
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/inflect"
 	"github.com/jdkato/regexp"
 	"github.com/mitchellh/mapstructure"
 )
@@ -21,9 +24,14 @@ type Substitution struct {
 	// `pos` (`string`): A regular expression matching tokens to parts of
 	// speech.
 	POS string
+	// `inflect` (`bool`): Extends `swap` to the plural, possessive, and verb
+	// forms of its (non-regex) entries -- e.g., `utilize: use` also swaps
+	// "utilized" for "used".
+	Inflect bool
 
 	pattern *regexp.Regexp
 	repl    []string
+	groups  []int
 }
 
 // NewSubstitution creates a new `substitution`-based rule.
@@ -43,28 +51,31 @@ func NewSubstitution(cfg *core.Config, generic baseCheck) (Substitution, error)
 		func() bool { return !rule.Nonword },
 		func() string { return "" }, true)
 
+	if rule.Inflect {
+		rule.Swap = inflectSwap(rule.Swap)
+	}
+
 	replacements := []string{}
+	groups := []int{}
+
+	index := 1
 	for regexstr, replacement := range rule.Swap {
-		opens := strings.Count(regexstr, "(")
-		if opens != strings.Count(regexstr, "?:") &&
-			opens != strings.Count(regexstr, `\(`) {
-			// We rely on manually-added capture groups to associate a match
-			// with its replacement -- e.g.,
-			//
-			//    `(foo)|(bar)`, [replacement1, replacement2]
-			//
-			// where the first capture group ("foo") corresponds to the first
-			// element of the replacements slice ("replacement1"). This means
-			// that we can only accept non-capture groups from the user (the
-			// indexing would be mixed up otherwise).
-			//
-			// TODO: Should we change this? Perhaps by creating a map of regex
-			// to replacements?
-			return rule, core.NewE201FromTarget(
-				"capture group not supported; use '(?:' instead of '('", regexstr, path)
+		// We rely on a wrapping capture group to associate a match with its
+		// replacement -- e.g.,
+		//
+		//    `(foo)|(bar)`, [replacement1, replacement2]
+		//
+		// Since the user may include their own capture groups (which can be
+		// referenced as `$1` or `${name}` in `swap` and `message`), we track
+		// the index of each wrapping group.
+		sub, err := regexp.Compile(regexstr)
+		if err != nil {
+			return rule, core.NewE201FromTarget(err.Error(), regexstr, path)
 		}
 		tokens += `(` + regexstr + `)|`
 		replacements = append(replacements, replacement)
+		groups = append(groups, index)
+		index += sub.NumSubexp() + 1
 	}
 	regex = fmt.Sprintf(regex, strings.TrimRight(tokens, "|"))
 
@@ -75,62 +86,75 @@ func NewSubstitution(cfg *core.Config, generic baseCheck) (Substitution, error)
 
 	rule.pattern = re
 	rule.repl = replacements
+	rule.groups = groups
 	return rule, nil
 }
 
 // Run executes the the `substitution`-based rule.
 //
 // The rule looks for one pattern and then suggests a replacement.
-func (s Substitution) Run(txt string, f *core.File) []core.Alert {
+func (s Substitution) Run(ctx context.Context, txt string, f *core.File) ([]core.Alert, error) {
 	alerts := []core.Alert{}
 	pos := false
 
 	// Leave early if we can to avoid calling `FindAllStringSubmatchIndex`
 	// unnecessarily.
 	if !s.pattern.MatchString(txt) {
-		return alerts
+		return alerts, nil
 	}
 
 	for _, submat := range s.pattern.FindAllStringSubmatchIndex(txt, -1) {
-		for idx, mat := range submat {
-			if mat != -1 && idx > 0 && idx%2 == 0 {
-				loc := []int{mat, submat[idx+1]}
-				// Based on the current capture group (`idx`), we can determine
-				// the associated replacement string by using the `repl` slice:
-				expected := s.repl[(idx/2)-1]
-				observed := strings.TrimSpace(txt[loc[0]:loc[1]])
-				if !matchToken(expected, observed, s.Ignorecase) {
-					if s.POS != "" {
-						// If we're given a POS pattern, check that it matches.
-						//
-						// If it doesn't match, the alert doesn't get added to
-						// a File (i.e., `hide` == true).
-						pos = core.CheckPOS(loc, s.POS, txt)
-					}
-					action := s.Fields().Action
-					if action.Name == "replace" && len(action.Params) == 0 {
-						action.Params = strings.Split(expected, "|")
-						expected = core.ToSentence(action.Params, "or")
-
-						// NOTE: For backwards-compatibility, we need to ensure
-						// that we don't double quote.
-						s.Message = convertMessage(s.Message)
-					}
-					a := core.Alert{
-						Check: s.Name, Severity: s.Level, Span: loc,
-						Link: s.Link, Hide: pos, Match: observed,
-						Action: s.Action}
-
-					a.Message, a.Description = formatMessages(s.Message,
-						s.Description, expected, observed)
-
-					alerts = append(alerts, a)
+		for i, idx := range s.groups {
+			if submat[2*idx] == -1 {
+				continue
+			}
+
+			last := s.pattern.NumSubexp()
+			if i+1 < len(s.groups) {
+				last = s.groups[i+1] - 1
+			}
+			lookup := re2Groups(s.pattern, txt, submat, idx, last)
+
+			loc := []int{submat[2*idx], submat[2*idx+1]}
+			// Based on the current capture group (`idx`), we can determine
+			// the associated replacement string by using the `repl` slice:
+			expected := expandGroups(s.repl[i], lookup)
+			observed := strings.TrimSpace(txt[loc[0]:loc[1]])
+			if !matchToken(expected, observed, s.Ignorecase) {
+				if s.POS != "" {
+					// If we're given a POS pattern, check that it matches.
+					//
+					// If it doesn't match, the alert doesn't get added to
+					// a File (i.e., `hide` == true).
+					pos = core.CheckPOS(loc, s.POS, txt)
 				}
+				action := s.Fields().Action
+				if action.Name == "replace" && len(action.Params) == 0 {
+					action.Params = strings.Split(expected, "|")
+					expected = core.ToSentence(action.Params, "or")
+
+					// NOTE: For backwards-compatibility, we need to ensure
+					// that we don't double quote.
+					s.Message = convertMessage(s.Message)
+				}
+				a := core.Alert{
+					Check: s.Name, Severity: s.Level, Span: loc,
+					Link: s.Link, Hide: pos, Match: observed,
+					Action: s.Action}
+
+				lookup = escapeLookup(lookup)
+				a.Message, a.Description = formatMessages(
+					expandGroups(s.Message, lookup),
+					expandGroups(s.Description, lookup),
+					expected, observed)
+
+				alerts = append(alerts, a)
 			}
+			break
 		}
 	}
 
-	return alerts
+	return alerts, nil
 }
 
 // Fields provides access to the internal rule definition.
@@ -151,3 +175,48 @@ func convertMessage(s string) string {
 	}
 	return s
 }
+
+// inflectSwap adds the inflected forms of each literal `observed: expected`
+// pair to `swap`.
+func inflectSwap(swap map[string]string) map[string]string {
+	inflected := make(map[string]string)
+	for observed, expected := range swap {
+		inflected[observed] = expected
+	}
+
+	// NOTE: We visit the entries (and their forms) in order so that a form
+	// produced more than once is always swapped for the same replacement.
+	keys := []string{}
+	for observed := range swap {
+		keys = append(keys, observed)
+	}
+	sort.Strings(keys)
+
+	for _, observed := range keys {
+		expected := swap[observed]
+		if !isLiteral(observed) {
+			continue
+		}
+
+		options := strings.Split(expected, "|")
+		forms := inflect.Inflections(observed)
+		for _, name := range inflect.Forms {
+			form, ok := forms[name]
+			if _, found := inflected[form]; !ok || found {
+				continue
+			}
+
+			repls := []string{}
+			for _, opt := range options {
+				if repl, ok := inflect.Inflections(opt)[name]; ok && isLiteral(opt) {
+					repls = append(repls, repl)
+				} else {
+					repls = append(repls, opt)
+				}
+			}
+			inflected[form] = strings.Join(repls, "|")
+		}
+	}
+
+	return inflected
+}
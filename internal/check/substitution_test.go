@@ -0,0 +1,116 @@
+package check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+func TestSubstitutionGroups(t *testing.T) {
+	def := baseCheck{
+		"path":    "",
+		"message": "Use '%s' instead of '%s' ($1).",
+		"swap": map[string]string{
+			`version (\d+)`:       "v$1",
+			`(?P<verb>utilize)s?`: "use",
+		},
+	}
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewSubstitution(cfg, def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts, err := rule.Run(context.Background(), "We utilize version 3.", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("expected two alerts, not %v", alerts)
+	}
+
+	expected := []string{
+		"Use 'use' instead of 'utilize' (utilize).",
+		"Use 'v3' instead of 'version 3' (3).",
+	}
+	for i, a := range alerts {
+		if a.Message != expected[i] {
+			t.Errorf("expected = %q, got = %q", expected[i], a.Message)
+		}
+	}
+}
+
+func TestExpandGroups(t *testing.T) {
+	lookup := func(ref string) (string, bool) {
+		groups := map[string]string{"1": "one", "name": "two"}
+		s, ok := groups[ref]
+		return s, ok
+	}
+
+	cases := map[string]string{
+		"$1 and ${name}": "one and two",
+		"${1}x":          "onex",
+		"costs $5":       "costs $5",
+		"$$1 and $1":     "$1 and one",
+		"costs $$5":      "costs $$5",
+		"trailing $":     "trailing $",
+	}
+	for in, out := range cases {
+		if s := expandGroups(in, lookup); s != out {
+			t.Errorf("expected = %q, got = %q", out, s)
+		}
+	}
+}
+
+func TestSubstitutionInflect(t *testing.T) {
+	def := baseCheck{
+		"path":    "",
+		"message": "Use '%s' instead of '%s'.",
+		"inflect": true,
+		"swap":    map[string]string{"utilize": "use"},
+	}
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := NewSubstitution(cfg, def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts, err := rule.Run(context.Background(), "We utilized it and are utilizing it.", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("expected two alerts, not %v", alerts)
+	}
+
+	expected := []string{
+		"Use 'used' instead of 'utilized'.",
+		"Use 'using' instead of 'utilizing'.",
+	}
+	for i, a := range alerts {
+		if a.Message != expected[i] {
+			t.Errorf("expected = %q, got = %q", expected[i], a.Message)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package check
 
 import (
 	"strings"
+	"unicode"
 
 	"github.com/errata-ai/vale/v2/internal/core"
 	"github.com/jdkato/prose/transform"
@@ -15,6 +16,17 @@ func isMatch(r *regexp.Regexp, s string) bool {
 	return r != nil && r.String() != "" && r.MatchString(s)
 }
 
+// isLiteral determines if `s` is a plain word or phrase (as opposed to a
+// regular expression).
+func isLiteral(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !strings.ContainsRune(" -'", r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
 func makeExceptions(ignore []string) *regexp.Regexp {
 	s := ""
 	if len(ignore) > 0 {
@@ -52,7 +52,7 @@ func printVerboseAlert(f *core.File, wrap bool) (int, int, int) {
 	var loc, level string
 	var errors, warnings, notifications int
 
-	alerts := f.SortedAlerts()
+	alerts := fileAlerts(f)
 	if len(alerts) == 0 {
 		return 0, 0, 0
 	}
@@ -76,6 +76,11 @@ func printVerboseAlert(f *core.File, wrap bool) (int, int, int) {
 			errors++
 		}
 		loc = fmt.Sprintf("%d:%d", a.Line, a.Span[0])
+		if a.Cell != nil {
+			loc += fmt.Sprintf(" (cell %d, %d:%d)", a.Cell.Index, a.Cell.Line, a.Cell.Span[0])
+		} else if a.Include != nil {
+			loc += fmt.Sprintf(" (%s, %d:%d)", a.Include.Path, a.Include.Line, a.Include.Span[0])
+		}
 		table.Append([]string{loc, level, a.Message, a.Check})
 	}
 	table.Render()
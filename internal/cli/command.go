@@ -4,21 +4,31 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 
+	"github.com/errata-ai/vale/v2/internal/check"
 	"github.com/errata-ai/vale/v2/internal/core"
 )
 
 var commandInfo = map[string]string{
 	"ls-config": "Print the current configuration to stdout and exit.",
+	"ls-rules":  "Print the loaded rules (and their tags) to stdout and exit.",
 }
 
 // Actions are the available CLI commands.
 var Actions = map[string]func(args []string, cfg *core.Config) error{
 	"ls-config": printConfig,
 	"dc":        printConfig,
+	"ls-rules":  printRules,
 	"help":      printUsage,
 }
 
+// NeedsConfig are the commands that can't run without a valid
+// configuration.
+var NeedsConfig = map[string]bool{
+	"ls-rules": true,
+}
+
 func printConfig(args []string, cfg *core.Config) error {
 	cfg, err := core.NewConfig(&Flags)
 	if err != nil {
@@ -34,6 +44,37 @@ func printConfig(args []string, cfg *core.Config) error {
 	return err
 }
 
+// ruleInfo is the `ls-rules` summary of a loaded rule.
+type ruleInfo struct {
+	Name    string
+	Extends string
+	Level   string
+	Scope   []string
+	Tags    []string
+}
+
+func printRules(args []string, cfg *core.Config) error {
+	mgr, err := check.NewManager(cfg)
+	if err != nil {
+		return err
+	}
+
+	rules := []ruleInfo{}
+	for name, rule := range mgr.Rules() {
+		def := rule.Fields()
+		rules = append(rules, ruleInfo{
+			Name: name, Extends: def.Extends, Level: def.Level,
+			Scope: def.Scope, Tags: def.Tags})
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+
+	fmt.Println(getJSON(rules))
+	return nil
+}
+
 func printUsage(args []string, cfg *core.Config) error {
 	flag.Usage()
 	return nil
@@ -38,10 +38,11 @@ func PrintCustomAlerts(linted []*core.File, path string) (bool, error) {
 
 	formatted := []ProcessedFile{}
 	for _, f := range linted {
-		if len(f.Alerts) == 0 {
+		alerts := fileAlerts(f)
+		if len(alerts) == 0 {
 			continue
 		}
-		for _, a := range f.SortedAlerts() {
+		for _, a := range alerts {
 			if a.Severity == "error" {
 				alertCount++
 				break
@@ -49,7 +50,7 @@ func PrintCustomAlerts(linted []*core.File, path string) (bool, error) {
 		}
 		formatted = append(formatted, ProcessedFile{
 			Path:   f.Path,
-			Alerts: f.Alerts,
+			Alerts: alerts,
 		})
 	}
 
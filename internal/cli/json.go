@@ -11,7 +11,7 @@ func PrintJSONAlerts(linted []*core.File) bool {
 	alertCount := 0
 	formatted := map[string][]core.Alert{}
 	for _, f := range linted {
-		for _, a := range f.SortedAlerts() {
+		for _, a := range fileAlerts(f) {
 			if a.Severity == "error" {
 				alertCount++
 			}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/denisbrodbeck/machineid"
+	"github.com/errata-ai/vale/v2/internal/core"
 )
 
 // Response is returned after an action.
@@ -16,6 +17,16 @@ type Response struct {
 	Error   string
 }
 
+// fileAlerts returns all of `f`'s alerts, including those for any rules that
+// failed to run.
+func fileAlerts(f *core.File) []core.Alert {
+	alerts := []core.Alert{}
+	for _, e := range f.Errors {
+		alerts = append(alerts, e.Alert())
+	}
+	return append(alerts, f.SortedAlerts()...)
+}
+
 func pluralize(s string, n int) string {
 	if n != 1 {
 		return s + "s"
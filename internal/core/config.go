@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/errata-ai/vale/v2/pkg/inflect"
 	"github.com/gobwas/glob"
 )
 
@@ -32,26 +33,52 @@ type CLIFlags struct {
 	Wrap       bool
 }
 
+// LTOptions controls the requests we make to a LanguageTool server.
+//
+// Empty values are left to the server (or, for the rule and category lists,
+// to our own defaults).
+type LTOptions struct {
+	Language           string   // overrides the File's `Lang`
+	MotherTongue       string   // used to detect "false friends"
+	EnabledRules       []string // rule IDs to turn on
+	DisabledRules      []string // rule IDs to turn off
+	EnabledCategories  []string // category IDs to turn on
+	DisabledCategories []string // category IDs to turn off
+	Retries            int      // how many times to retry a failed request
+}
+
 // Config holds the the configuration values from both the CLI and `.vale.ini`.
 type Config struct {
 	// General configuration
 	BlockIgnores   map[string][]string        // A list of blocks to ignore
+	Budgets        map[string]int             // Per-document alert budgets, by level
 	Checks         []string                   // All checks to load
 	Formats        map[string]string          // A map of unknown -> known formats
 	GBaseStyles    []string                   // Global base style
 	GChecks        map[string]bool            // Global checks
+	GLang          string                     // Global language
+	GTags          map[string]bool            // Global tag settings
 	IgnoredClasses []string                   // A list of HTML classes to ignore
 	IgnoredScopes  []string                   // A list of HTML tags to ignore
+	JSXProps       map[string][]string        // Syntax-specific JSX props to lint
+	LintKeys       map[string][]string        // Syntax-specific data keys to lint
+	LintSource     map[string]bool            // Syntax-specific choice to lint source (not target) text
 	MinAlertLevel  int                        // Lowest alert level to display
 	Project        string                     // The active project
+	ReadingOrder   []string                   // Files in the order they're meant to be read
+	Root           string                     // The directory of the loaded config file
 	RuleToLevel    map[string]string          // Single-rule level changes
 	SBaseStyles    map[string][]string        // Syntax-specific base styles
 	SChecks        map[string]map[string]bool // Syntax-specific checks
+	SLang          map[string]string          // Syntax-specific languages
+	STags          map[string]map[string]bool // Syntax-specific tag settings
+	SkippedMacros  map[string][]string        // Syntax-specific LaTeX macros to ignore
 	SkippedScopes  []string                   // A list of HTML blocks to ignore
 	Stylesheets    map[string]string          // XSLT stylesheet
 	StylesPath     string                     // Directory with Rule.yml files
 	TokenIgnores   map[string][]string        // A list of tokens to ignore
 	WordTemplate   string                     // The template used in YAML -> regexp list conversions
+	XMLScopes      map[string][]string        // Syntax-specific XML scope mappings
 
 	AcceptedTokens map[string]struct{} `json:"-"` // Project-specific vocabulary (okay)
 	RejectedTokens map[string]struct{} `json:"-"` // Project-specific vocabulary (avoid)
@@ -63,6 +90,7 @@ type Config struct {
 	SphinxAuto  string `json:"-"` // Should we call `sphinx-build`?
 
 	FallbackPath string               `json:"-"`
+	LTOptions    LTOptions            `json:"-"`
 	LTPath       string               `json:"-"`
 	SecToPat     map[string]glob.Glob `json:"-"`
 	Styles       []string             `json:"-"`
@@ -79,19 +107,30 @@ func NewConfig(flags *CLIFlags) (*Config, error) {
 
 	cfg.AcceptedTokens = make(map[string]struct{})
 	cfg.BlockIgnores = make(map[string][]string)
+	cfg.Budgets = make(map[string]int)
 	cfg.Flags = flags
 	cfg.Formats = make(map[string]string)
 	cfg.GChecks = make(map[string]bool)
+	cfg.GLang = DefaultLang
+	cfg.GTags = make(map[string]bool)
+	cfg.JSXProps = make(map[string][]string)
+	cfg.LTOptions = LTOptions{MotherTongue: "en", Retries: 2}
 	cfg.LTPath = "http://localhost:8081/v2/check"
+	cfg.LintKeys = make(map[string][]string)
+	cfg.LintSource = make(map[string]bool)
 	cfg.MinAlertLevel = 1
 	cfg.RejectedTokens = make(map[string]struct{})
 	cfg.RuleToLevel = make(map[string]string)
 	cfg.SBaseStyles = make(map[string][]string)
 	cfg.SChecks = make(map[string]map[string]bool)
+	cfg.SLang = make(map[string]string)
+	cfg.STags = make(map[string]map[string]bool)
 	cfg.SecToPat = make(map[string]glob.Glob)
+	cfg.SkippedMacros = make(map[string][]string)
 	cfg.Stylesheets = make(map[string]string)
 	cfg.Timeout = 2
 	cfg.TokenIgnores = make(map[string][]string)
+	cfg.XMLScopes = make(map[string][]string)
 	cfg.Paths = []string{""}
 
 	return &cfg, nil
@@ -107,18 +146,27 @@ func (c *Config) AddWordListFile(name string, accept bool) error {
 	return c.addWordList(fd, accept)
 }
 
+// addWordList adds each term in `r` (one per line) to our vocabulary.
+//
+// A term that starts with '~' (e.g., `~utilize`) also adds its plural,
+// possessive, and verb forms (e.g., "utilized").
 func (c *Config) addWordList(r io.Reader, accept bool) error {
 	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		word := strings.TrimSpace(scanner.Text())
 		if len(word) == 0 || word == "#" {
 			continue
-		} else if accept {
-			if _, ok := c.AcceptedTokens[word]; !ok {
+		}
+
+		words := []string{word}
+		if strings.HasPrefix(word, "~") {
+			words = inflect.Expand(strings.TrimSpace(word[1:]))
+		}
+
+		for _, word := range words {
+			if accept {
 				c.AcceptedTokens[word] = struct{}{}
-			}
-		} else {
-			if _, ok := c.RejectedTokens[word]; !ok {
+			} else {
 				c.RejectedTokens[word] = struct{}{}
 			}
 		}
@@ -57,6 +57,49 @@ func NewLinedBlock(ctx, txt, sel string, line int) Block {
 		Line:    line}
 }
 
+// A Section represents a heading and the block-level content that follows it.
+//
+// Any content that precedes a document's first heading is assigned to a
+// Section with a `Level` of 0.
+type Section struct {
+	Level  int      // the heading level -- e.g., 2 for `<h2>`
+	Text   string   // the heading's text
+	Line   int      // the (1-based) source line of the heading (or first block)
+	Blocks []string // block types -- e.g., "paragraph", "list", or "table"
+}
+
+// A Hyperlink represents an `<a href>` found in a markup document.
+type Hyperlink struct {
+	Href     string // the raw `href` value
+	Text     string // the link's text
+	External bool   // does `Href` point to another host?
+	Broken   string // why the link couldn't be resolved: "file", "anchor", or ""
+	Target   string // the local file that `Anchor` must be found in
+	Anchor   string // the link's (unescaped) `#anchor`, if any
+	Line     int    // the link's (1-based) line, if known
+	Span     []int  // the link's [begin, end] location within its line
+}
+
+// A Segment records where a part of a File's `Prose` came from.
+type Segment struct {
+	Offset int // the segment's (rune) offset in the Prose
+	Line   int // the (1-based) source line of the segment's first line
+}
+
+// A RuleError records a rule that couldn't be applied to a File.
+type RuleError struct {
+	Check   string // the name of the rule
+	Message string // what went wrong
+}
+
+// Alert converts a RuleError into a document-level Alert, which allows it to
+// be reported alongside a File's other alerts.
+func (e RuleError) Alert() Alert {
+	return Alert{
+		Check: e.Check, Severity: "error", Line: 1, Span: []int{1, 1},
+		Message: "The rule failed to run: " + e.Message}
+}
+
 // A File represents a linted text file.
 type File struct {
 	Alerts     []Alert           // all alerts associated with this file
@@ -65,17 +108,36 @@ type File struct {
 	ChkToCtx   map[string]string // maps a temporary context to a particular check
 	Comments   map[string]bool   // comment control statements
 	Content    string            // the raw file contents
-	Format     string            // 'code', 'markup' or 'prose'
+	Errors     []RuleError       // rules that failed to run on this file
+	Format     string            // 'code', 'commit', 'data', 'markup' or 'prose'
+	IDs        map[string]bool   // the IDs (i.e., link anchors) of the File's elements
+	JSXProps   []string          // syntax-specific JSX props to lint
+	Lang       string            // the language (e.g., "en-US") of the File's content
 	Lines      []string          // the File's Content split into lines
+	Links      []Hyperlink       // all links found in the File
+	LintKeys   []string          // syntax-specific data keys to lint
+	LintSource bool              // lint the source (rather than target) text of translations
+	Macros     []string          // syntax-specific LaTeX macros to skip
 	NormedExt  string            // the normalized extension (see util/format.go)
+	Outline    []Section         // the document's heading structure
 	Path       string            // the full path
+	Pending    []Alert           // alerts that aren't reported until all files have been linted
+	Prose      bytes.Buffer      // holds all of the File's prose (including its headings, lists, and tables)
 	Transform  string            // XLST transform
 	RealExt    string            // actual file extension
+	Segments   []Segment         // the source of each part of Prose
 	Sequences  []string          // tracks various info (e.g., defined abbreviations)
 	Summary    bytes.Buffer      // holds content to be included in summarization checks
+	Tags       map[string]bool   // syntax-specific tag settings assigned in .vale
+	XMLScopes  []string          // syntax-specific XML scope mappings
 
+	budgets  map[string]int
 	history  map[string]int
+	hits     map[string]int
 	limits   map[string]int
+	minLevel int
+	spent    map[string]int
+	deferred bool
 	isGlobal bool
 	simple   bool
 }
@@ -100,8 +162,57 @@ type Alert struct {
 	Span        []int  // the [begin, end] location within a line
 	Match       string // the actual matched text
 
+	Tags []string `json:",omitempty"` // the tags of the check
+
+	// Cell locates the alert within a cell of a Jupyter notebook, whose
+	// alerts are otherwise reported by their location in its JSON.
+	Cell *Cell `json:",omitempty"`
+
+	// Include locates the alert within a file included by the linted file
+	// (e.g., using AsciiDoc's `include::` directive), whose alerts are
+	// otherwise reported at the line of the directive that includes them.
+	Include *Include `json:",omitempty"`
+
+	// Entry is the ID of the localization entry (e.g., a `.po` msgid) that
+	// the alert was found in.
+	Entry string `json:",omitempty"`
+
 	Hide  bool `json:"-"` // should we hide this alert?
 	Limit int  `json:"-"` // the max times to report
+
+	// Escalate maps a level to the number of times the check may be
+	// reported in a document before its alerts are raised to that level.
+	Escalate map[string]int `json:"-"`
+
+	// Group and Variant identify the option matched by a project-scoped
+	// rule, which is only reported once all files have been linted.
+	Group   string `json:"-"`
+	Variant string `json:"-"`
+
+	// Signature is the MinHash fingerprint of a block checked by a
+	// `duplicate` rule, which is compared to other blocks once all files
+	// have been linted.
+	Signature []uint64 `json:"-"`
+
+	// Target and Anchor identify a link to another file (and its anchor),
+	// which is only reported if -- once all files have been linted -- the
+	// anchor can't be found in that file.
+	Target string `json:"-"`
+	Anchor string `json:"-"`
+}
+
+// A Cell is the location of an Alert within a Jupyter notebook cell.
+type Cell struct {
+	Index int   // the cell's (1-based) position in the notebook
+	Line  int   // the line within the cell
+	Span  []int // the [begin, end] location within Line
+}
+
+// An Include is the location of an Alert within an included file.
+type Include struct {
+	Path string // the included file's path
+	Line int    // the line within the included file
+	Span []int  // the [begin, end] location within Line
 }
 
 // A Plugin provides a means of extending Vale.
@@ -142,6 +253,80 @@ func (s Selector) ContainsString(scope []string) bool {
 	return false
 }
 
+// Matches determines if s satisfies a rule's `scope`.
+//
+// Each entry of `scope` is an expression of selectors joined by `&` (and) or
+// `|` (or), with `&` binding more tightly; a `~` prefix negates a selector
+// (e.g., `table & list` or `heading & ~heading.h1`). An entry that consists
+// of a single negated selector (e.g., `~heading`) is an exclusion: s matches
+// if it satisfies one of the other entries -- or `text`, if there aren't
+// any -- and none of the exclusions.
+func (s Selector) Matches(scope []string) bool {
+	matched, positive := false, false
+	for _, entry := range scope {
+		expr := strings.TrimSpace(entry)
+		if isExclusion(expr) {
+			if s.Contains(Selector{Value: []string{strings.TrimSpace(expr[1:])}}) {
+				return false
+			}
+			continue
+		}
+		positive = true
+		matched = matched || s.matchesExpr(expr)
+	}
+
+	if !positive {
+		return s.Has("text")
+	}
+	return matched
+}
+
+func (s Selector) matchesExpr(expr string) bool {
+	if !strings.ContainsAny(expr, "~&|") {
+		return s.Contains(Selector{Value: []string{expr}})
+	}
+
+	for _, alt := range strings.Split(expr, "|") {
+		all, positive := true, false
+		for _, term := range strings.Split(alt, "&") {
+			term = strings.TrimSpace(term)
+			negated := strings.HasPrefix(term, "~")
+			if negated {
+				term = strings.TrimSpace(term[1:])
+			} else {
+				positive = true
+			}
+			if s.Contains(Selector{Value: []string{term}}) == negated {
+				all = false
+				break
+			}
+		}
+		if all && (positive || s.Has("text")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isExclusion(expr string) bool {
+	return strings.HasPrefix(expr, "~") && !strings.ContainsAny(expr, "&|")
+}
+
+// ScopeTerms returns the selectors (without any negation) used in the scope
+// expression `expr` -- e.g., `heading & ~heading.h1` -> []string{"heading",
+// "heading.h1"}.
+func ScopeTerms(expr string) []string {
+	terms := []string{}
+	for _, alt := range strings.Split(expr, "|") {
+		for _, term := range strings.Split(alt, "&") {
+			term = strings.TrimPrefix(strings.TrimSpace(term), "~")
+			terms = append(terms, strings.TrimSpace(term))
+		}
+	}
+	return terms
+}
+
 // Equal determines if sel == s.
 func (s Selector) Equal(sel Selector) bool {
 	if len(s.Value) == len(sel.Value) {
@@ -206,6 +391,9 @@ func NewFile(src string, config *Config) (*File, error) {
 	old := filepath.Ext(fp)
 	if normed, found := config.Formats[strings.Trim(old, ".")]; found {
 		fp = fp[0:len(fp)-len(old)] + "." + normed
+	} else if IsCommitMsg(fp) {
+		// This allows a `[*.commit]` section to apply to COMMIT_EDITMSG.
+		fp += ".commit"
 	}
 
 	baseStyles := config.GBaseStyles
@@ -216,6 +404,26 @@ func NewFile(src string, config *Config) (*File, error) {
 		}
 	}
 
+	// NOTE: If more than one section sets an option, the most specific one
+	// wins (see `matchSections`).
+	sections := matchSections(fp, config)
+
+	lang := config.GLang
+	for _, sec := range sections {
+		if l, found := config.SLang[sec]; found {
+			lang = l
+			break
+		}
+	}
+
+	source := false
+	for _, sec := range sections {
+		if s, found := config.LintSource[sec]; found {
+			source = s
+			break
+		}
+	}
+
 	checks := make(map[string]bool)
 	for sec, smap := range config.SChecks {
 		if pat, found := config.SecToPat[sec]; found && pat.Match(fp) {
@@ -224,6 +432,14 @@ func NewFile(src string, config *Config) (*File, error) {
 		}
 	}
 
+	tags := make(map[string]bool)
+	for _, sec := range sections {
+		if smap, found := config.STags[sec]; found {
+			tags = smap
+			break
+		}
+	}
+
 	transform := ""
 	for sec, p := range config.Stylesheets {
 		pat, err := glob.Compile(sec)
@@ -235,6 +451,38 @@ func NewFile(src string, config *Config) (*File, error) {
 		}
 	}
 
+	xmlScopes := []string{}
+	for _, sec := range sections {
+		if entries, found := config.XMLScopes[sec]; found {
+			xmlScopes = entries
+			break
+		}
+	}
+
+	jsxProps := []string{}
+	for _, sec := range sections {
+		if props, found := config.JSXProps[sec]; found {
+			jsxProps = props
+			break
+		}
+	}
+
+	skipped := []string{}
+	for _, sec := range sections {
+		if macros, found := config.SkippedMacros[sec]; found {
+			skipped = macros
+			break
+		}
+	}
+
+	lintKeys := []string{}
+	for _, sec := range sections {
+		if keys, found := config.LintKeys[sec]; found {
+			lintKeys = keys
+			break
+		}
+	}
+
 	content := Sanitize(string(fbytes))
 	lines := strings.SplitAfter(content, "\n")
 	file := File{
@@ -242,12 +490,116 @@ func NewFile(src string, config *Config) (*File, error) {
 		BaseStyles: baseStyles, Checks: checks, Lines: lines, Content: content,
 		Comments: make(map[string]bool), history: make(map[string]int),
 		simple: config.Flags.Simple, Transform: transform,
-		limits: make(map[string]int),
+		limits: make(map[string]int), Lang: lang, Tags: tags,
+		budgets: config.Budgets, hits: make(map[string]int),
+		spent: make(map[string]int), minLevel: config.MinAlertLevel,
+		XMLScopes: xmlScopes, JSXProps: jsxProps, Macros: skipped,
+		LintKeys: lintKeys, LintSource: source,
 	}
 
 	return &file, nil
 }
 
+// matchSections returns the sections of `config` whose patterns match `fp`,
+// from most to least specific: a longer (i.e., presumably more specific)
+// pattern comes first -- e.g., `*.de.md` before `*.md` -- with ties broken
+// lexically.
+func matchSections(fp string, config *Config) []string {
+	sections := []string{}
+	for sec, pat := range config.SecToPat {
+		if pat.Match(fp) {
+			sections = append(sections, sec)
+		}
+	}
+
+	sort.Slice(sections, func(i, j int) bool {
+		a, b := sections[i], sections[j]
+		return len(a) > len(b) || (len(a) == len(b) && a < b)
+	})
+
+	return sections
+}
+
+// Fork returns a File for a part of f -- e.g., a cell in a Jupyter notebook
+// -- that has its own content and extension.
+//
+// The new File shares f's settings, comment state, limits, and budgets, but
+// it has its own alerts, errors, and document structure. Its alerts are
+// located (within its content) but not yet counted: the caller maps them to
+// their location in f and then adds them with `f.AddLocatedAlert`.
+func (f *File) Fork(content, ext, normed, format string) *File {
+	sub := *f
+
+	sub.Content, sub.Lines = content, strings.SplitAfter(content, "\n")
+	sub.RealExt, sub.NormedExt, sub.Format = ext, normed, format
+
+	sub.Alerts, sub.Errors, sub.Pending = nil, nil, nil
+	sub.IDs, sub.Links, sub.Outline = nil, nil, nil
+	sub.Summary, sub.Prose, sub.Segments = bytes.Buffer{}, bytes.Buffer{}, nil
+	sub.history = make(map[string]int)
+	sub.deferred = true
+
+	return &sub
+}
+
+// AddProse adds `txt`, whose first line is the source line `line`, to f's
+// Prose and, if `summary` is true, to its Summary.
+func (f *File) AddProse(txt string, line int, summary bool) {
+	f.Segments = append(f.Segments, Segment{
+		Offset: utf8.RuneCount(f.Prose.Bytes()), Line: line})
+	f.Prose.WriteString(txt + " ")
+	if summary {
+		f.Summary.WriteString(txt + " ")
+	}
+}
+
+// ProseLoc maps `match`, found at the (rune) offset `offset` of f's Prose,
+// to its line and span in f's source.
+//
+// It reports false if the match's source line is unknown or doesn't
+// contain the match.
+func (f *File) ProseLoc(offset int, match string) (int, []int, bool) {
+	seg := -1
+	for i, s := range f.Segments {
+		if s.Offset > offset {
+			break
+		}
+		seg = i
+	}
+
+	prose := []rune(f.Prose.String())
+	if seg < 0 || offset > len(prose) || match == "" {
+		return 0, nil, false
+	}
+
+	before := string(prose[f.Segments[seg].Offset:offset])
+	line := f.Segments[seg].Line + strings.Count(before, "\n")
+	if line < 1 || line > len(f.Lines) {
+		return 0, nil, false
+	}
+
+	// We skip as many occurrences of the match in the source line as there
+	// are before it in the prose's line.
+	skip := strings.Count(before[strings.LastIndex(before, "\n")+1:], match)
+
+	src, pos := f.Lines[line-1], -1
+	for i, start := 0, 0; i <= skip; i++ {
+		idx := strings.Index(src[start:], match)
+		if idx < 0 {
+			break
+		}
+		pos = start + idx
+		start = pos + len(match)
+	}
+
+	if pos < 0 {
+		return 0, nil, false
+	}
+
+	col := utf8.RuneCountInString(src[:pos]) + 1
+	return line, []int{col, col + utf8.RuneCountInString(match) - 1}, true
+}
+
 // SortedAlerts returns all of f's alerts sorted by line and column.
 func (f *File) SortedAlerts() []Alert {
 	sort.Sort(ByPosition(f.Alerts))
@@ -330,40 +682,115 @@ func (f *File) AddAlert(a Alert, blk Block, lines, pad int, lookup bool) {
 		ctx = old
 	}
 
-	if !lookup {
-		a.Line, a.Span = f.assignLoc(ctx, blk, pad, a)
-	}
-	if (!lookup && a.Span[0] < 0) || lookup {
-		a.Line, a.Span = f.FindLoc(ctx, blk.Text, pad, lines, a)
+	// NOTE: An alert with a line has already been located by its rule (e.g.,
+	// using `ProseLoc`).
+	if a.Line == 0 {
+		if !lookup {
+			a.Line, a.Span = f.assignLoc(ctx, blk, pad, a)
+		}
+		if (!lookup && a.Span[0] < 0) || lookup {
+			a.Line, a.Span = f.FindLoc(ctx, blk.Text, pad, lines, a)
+		}
 	}
 
 	if a.Span[0] > 0 {
 		f.ChkToCtx[a.Check], _ = Substitute(ctx, a.Match, '#')
 		if !a.Hide {
-			// Ensure that we're not double-reporting an Alert:
-			entry := strings.Join([]string{
-				strconv.Itoa(a.Line),
-				strconv.Itoa(a.Span[0]),
-				a.Check}, "-")
-
-			if _, found := f.history[entry]; !found {
-				// Check rule-assigned limits for reporting:
-				count, found := f.limits[a.Check]
-				if (!found || a.Limit == 0) || count < a.Limit {
-					f.Alerts = append(f.Alerts, a)
-
-					f.history[entry] = 1
-					if a.Limit > 0 {
-						f.limits[a.Check]++
-					}
-				}
-			}
+			f.AddLocatedAlert(a)
+		}
+	}
+}
+
+// AddLocatedAlert adds an Alert, whose location has already been calculated,
+// to a File -- subject to its rule's `limit` and the document's budgets.
+//
+// Provisional alerts (see `Group`, `Signature`, and `Target`) are held in `Pending`
+// instead, since they don't count toward the limit or budgets unless they're
+// reported.
+func (f *File) AddLocatedAlert(a Alert) {
+	if (a.Group != "" || len(a.Signature) > 0 || a.Target != "") && !f.deferred {
+		f.Pending = append(f.Pending, a)
+		return
+	}
+
+	// Ensure that we're not double-reporting an Alert:
+	entry := strings.Join([]string{
+		strconv.Itoa(a.Line),
+		strconv.Itoa(a.Span[0]),
+		a.Check}, "-")
+	if a.Include != nil {
+		// All of an included file's alerts share the same location.
+		entry += strings.Join([]string{"",
+			a.Include.Path,
+			strconv.Itoa(a.Include.Line),
+			strconv.Itoa(a.Include.Span[0])}, "-")
+	}
+
+	if _, found := f.history[entry]; found {
+		return
+	} else if f.deferred {
+		f.history[entry] = 1
+		f.Alerts = append(f.Alerts, a)
+		return
+	}
+
+	// Check rule-assigned limits for reporting:
+	count, found := f.limits[a.Check]
+	if (!found || a.Limit == 0) || count < a.Limit {
+		f.history[entry] = 1
+		if a.Limit > 0 {
+			f.limits[a.Check]++
+		}
+
+		if f.withinBudget(&a) {
+			f.Alerts = append(f.Alerts, a)
 		}
 	}
 }
 
+// withinBudget escalates `a` (if its check has been reported often enough)
+// and then reports whether the document's budget for its level allows it to
+// be added.
+func (f *File) withinBudget(a *Alert) bool {
+	f.hits[a.Check]++
+	for level, threshold := range a.Escalate {
+		if threshold > 0 && f.hits[a.Check] > threshold &&
+			LevelToInt[level] > LevelToInt[a.Severity] {
+			a.Severity = level
+		}
+	}
+
+	if len(a.Escalate) > 0 && LevelToInt[a.Severity] < f.minLevel {
+		// The rule only ran because it could be escalated to (at least)
+		// `MinAlertLevel`.
+		return false
+	}
+
+	budget := f.budgets[a.Severity]
+	if budget > 0 && f.spent[a.Severity] >= budget {
+		return false
+	}
+	f.spent[a.Severity]++
+
+	return true
+}
+
 var commentControlRE = regexp.MustCompile(`^vale (.+\..+) = (YES|NO)$`)
 
+// AddError records that the rule `check` failed to run.
+//
+// A rule that fails on many blocks (e.g., because a server is unavailable)
+// is only reported once per distinct error.
+func (f *File) AddError(check string, err error) {
+	e := RuleError{Check: check, Message: err.Error()}
+	for _, old := range f.Errors {
+		if old == e {
+			return
+		}
+	}
+	f.Errors = append(f.Errors, e)
+}
+
 // UpdateComments sets a new status based on comment.
 func (f *File) UpdateComments(comment string) {
 	if comment == "vale off" {
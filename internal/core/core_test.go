@@ -1,6 +1,7 @@
 package core
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -24,3 +25,63 @@ func TestSelectors(t *testing.T) {
 		}
 	}
 }
+
+var scopeTests = []struct {
+	block string
+	scope []string
+	match bool
+}{
+	{"text.md", []string{"text"}, true},
+	{"text.heading.h1.md", []string{"text", "~heading"}, false},
+	{"text.list.md", []string{"text", "~heading"}, true},
+	{"text.list.md", []string{"~heading"}, true},
+	{"summary.md", []string{"~heading"}, false},
+	{"text.heading.h1.md", []string{"heading & ~heading.h1"}, false},
+	{"text.heading.h2.md", []string{"heading & ~heading.h1"}, true},
+	{"text.table.cell.list.md", []string{"table & list"}, true},
+	{"text.list.md", []string{"table & list"}, false},
+	{"text.list.md", []string{"table | list"}, true},
+	{"text.heading.h3.md", []string{"heading.h2 | heading.h3 & ~list"}, true},
+	{"sentence.md", []string{"~heading & ~list"}, false},
+}
+
+func TestScopeMatches(t *testing.T) {
+	for _, tt := range scopeTests {
+		s := Selector{Value: []string{tt.block}}
+		if s.Matches(tt.scope) != tt.match {
+			t.Errorf("%s (%v): expected %v", tt.block, tt.scope, tt.match)
+		}
+	}
+}
+
+func TestAddWordList(t *testing.T) {
+	cfg, err := NewConfig(&CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = cfg.addWordList(strings.NewReader("~utilize\n~e-mail\nleverage\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, word := range []string{"utilize", "utilized", "utilizing", "e-mail", "e-mails", "leverage"} {
+		if _, ok := cfg.RejectedTokens[word]; !ok {
+			t.Errorf("expected %q in %v", word, cfg.RejectedTokens)
+		}
+	}
+	for _, word := range []string{"~utilize", "ed-mail", "leveraged"} {
+		if _, ok := cfg.RejectedTokens[word]; ok {
+			t.Errorf("unexpected %q in %v", word, cfg.RejectedTokens)
+		}
+	}
+}
+
+func TestSentenceTokenizerFor(t *testing.T) {
+	text := "Klicken Sie z.B. auf den Knopf. Dann ist es fertig."
+
+	sents := SentenceTokenizerFor("de-DE").Tokenize(text)
+	if len(sents) != 2 {
+		t.Errorf("expected 2 sentences, got %q", sents)
+	}
+}
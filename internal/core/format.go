@@ -60,38 +60,64 @@ var CommentsByNormedExt = map[string]map[string]string{
 }
 
 // FormatByExtension associates a file extension with its "normed" extension
-// and its format (markup, code or text).
+// and its format (markup, code, data, commit or text).
 var FormatByExtension = map[string][]string{
 	`\.(?:[rc]?py[3w]?|[Ss][Cc]onstruct)$`:        {".py", "code"},
 	`\.(?:adoc|asciidoc|asc)$`:                    {".adoc", "markup"},
 	`\.(?:cpp|cc|c|cp|cxx|c\+\+|h|hpp|h\+\+)$`:    {".c", "code"},
+	`\.(?:commit)$`:                               {".commit", "commit"},
 	`\.(?:cs|csx)$`:                               {".c", "code"},
 	`\.(?:css)$`:                                  {".css", "code"},
 	`\.(?:go)$`:                                   {".c", "code"},
 	`\.(?:html|htm|shtml|xhtml)$`:                 {".html", "markup"},
 	`\.(?:rb|Gemfile|Rakefile|Brewfile|gemspec)$`: {".rb", "code"},
+	`\.(?:ipynb)$`:                                {".ipynb", "markup"},
+	`\.(?:json)$`:                                 {".json", "data"},
 	`\.(?:java|bsh)$`:                             {".c", "code"},
 	`\.(?:js)$`:                                   {".c", "code"},
 	`\.(?:lua)$`:                                  {".lua", "code"},
 	`\.(?:md|mdown|markdown|markdn)$`:             {".md", "markup"},
+	`\.(?:mdx)$`:                                  {".mdx", "markup"},
+	`\.(?:org)$`:                                  {".org", "markup"},
+	`\.(?:toml)$`:                                 {".toml", "data"},
+	`\.(?:po|pot)$`:                               {".po", "data"},
 	`\.(?:php)$`:                                  {".php", "code"},
 	`\.(?:pl|pm|pod)$`:                            {".r", "code"},
 	`\.(?:r|R)$`:                                  {".r", "code"},
 	`\.(?:rs)$`:                                   {".rs", "code"},
 	`\.(?:rst|rest)$`:                             {".rst", "markup"},
 	`\.(?:swift)$`:                                {".c", "code"},
+	`\.(?:tex|latex|ltx)$`:                        {".tex", "markup"},
 	`\.(?:txt)$`:                                  {".txt", "text"},
 	`\.(?:sass|less)$`:                            {".c", "code"},
 	`\.(?:scala|sbt)$`:                            {".c", "code"},
 	`\.(?:hs)$`:                                   {".hs", "code"},
 	`\.(?:xml)$`:                                  {".xml", "markup"},
 	`\.(?:dita)$`:                                 {".dita", "markup"},
+	`\.(?:xliff|xlf)$`:                            {".xliff", "data"},
+	`\.(?:ya?ml)$`:                                {".yml", "data"},
+}
+
+// commitMsgFiles are the files, which lack an extension, that Git asks
+// editors (and `commit-msg` hooks) to read a message from.
+var commitMsgFiles = map[string]bool{
+	"COMMIT_EDITMSG": true, "MERGE_MSG": true, "SQUASH_MSG": true,
+	"TAG_EDITMSG": true,
+}
+
+// IsCommitMsg determines if `path` is a Git commit message file (e.g.,
+// `.git/COMMIT_EDITMSG`).
+func IsCommitMsg(path string) bool {
+	return commitMsgFiles[filepath.Base(path)]
 }
 
 // FormatFromExt takes a file extension and returns its [normExt, format]
 // list, if supported.
 func FormatFromExt(path string, mapping map[string]string) (string, string) {
 	ext := strings.Trim(filepath.Ext(path), ".")
+	if IsCommitMsg(path) {
+		ext = "commit"
+	}
 	if format, found := mapping[ext]; found {
 		ext = format
 	}
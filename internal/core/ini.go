@@ -6,9 +6,11 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/errata-ai/ini"
+	"github.com/errata-ai/vale/v2/pkg/xmlscope"
 	"github.com/gobwas/glob"
 )
 
@@ -42,6 +44,18 @@ var syntaxOpts = map[string]func(string, *ini.Section, *Config) error{
 		cfg.TokenIgnores[label] = sec.Key("TokenIgnores").Strings(",")
 		return nil
 	},
+	"Lang": func(label string, sec *ini.Section, cfg *Config) error {
+		cfg.SLang[label] = sec.Key("Lang").String()
+		return nil
+	},
+	"EnableTags": func(label string, sec *ini.Section, cfg *Config) error {
+		cfg.STags[label] = setTags(cfg.STags[label], sec, "EnableTags", true)
+		return nil
+	},
+	"DisableTags": func(label string, sec *ini.Section, cfg *Config) error {
+		cfg.STags[label] = setTags(cfg.STags[label], sec, "DisableTags", false)
+		return nil
+	},
 	"Transform": func(label string, sec *ini.Section, cfg *Config) error {
 		canidate := sec.Key("Transform").String()
 
@@ -54,6 +68,33 @@ var syntaxOpts = map[string]func(string, *ini.Section, *Config) error{
 		return nil
 
 	},
+	"JSXProps": func(label string, sec *ini.Section, cfg *Config) error {
+		cfg.JSXProps[label] = mergeValues(sec.Key("JSXProps").StringsWithShadows(","))
+		return nil
+	},
+	"LintKeys": func(label string, sec *ini.Section, cfg *Config) error {
+		cfg.LintKeys[label] = mergeValues(sec.Key("LintKeys").StringsWithShadows(","))
+		return nil
+	},
+	"LintSource": func(label string, sec *ini.Section, cfg *Config) error {
+		cfg.LintSource[label] = sec.Key("LintSource").MustBool(false)
+		return nil
+	},
+	"SkippedMacros": func(label string, sec *ini.Section, cfg *Config) error {
+		cfg.SkippedMacros[label] = mergeValues(sec.Key("SkippedMacros").StringsWithShadows(","))
+		return nil
+	},
+	"XMLScopes": func(label string, sec *ini.Section, cfg *Config) error {
+		entries := sec.Key("XMLScopes").Strings(",")
+		if _, err := xmlscope.New(entries); err != nil {
+			return NewE201FromTarget(
+				fmt.Sprintf("Invalid XMLScopes: %s.", err.Error()),
+				"XMLScopes",
+				cfg.Flags.Path)
+		}
+		cfg.XMLScopes[label] = entries
+		return nil
+	},
 }
 
 var globalOpts = map[string]func(*ini.Section, *Config, []string){
@@ -70,6 +111,15 @@ var globalOpts = map[string]func(*ini.Section, *Config, []string){
 	"TokenIgnores": func(sec *ini.Section, cfg *Config, args []string) {
 		cfg.TokenIgnores["*"] = sec.Key("TokenIgnores").Strings(",")
 	},
+	"Lang": func(sec *ini.Section, cfg *Config, args []string) {
+		cfg.GLang = sec.Key("Lang").MustString(DefaultLang)
+	},
+	"EnableTags": func(sec *ini.Section, cfg *Config, args []string) {
+		cfg.GTags = setTags(cfg.GTags, sec, "EnableTags", true)
+	},
+	"DisableTags": func(sec *ini.Section, cfg *Config, args []string) {
+		cfg.GTags = setTags(cfg.GTags, sec, "DisableTags", false)
+	},
 }
 
 var coreOpts = map[string]func(*ini.Section, *Config, []string) error{
@@ -138,10 +188,71 @@ var coreOpts = map[string]func(*ini.Section, *Config, []string) error{
 		cfg.Project = sec.Key("Vocab").String()
 		return loadVocab(cfg.Project, cfg)
 	},
+	"ReadingOrder": func(sec *ini.Section, cfg *Config, args []string) error {
+		for _, entry := range mergeValues(sec.Key("ReadingOrder").StringsWithShadows(",")) {
+			pattern := determinePath(cfg.Flags.Path, filepath.FromSlash(entry))
+
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				return NewE201FromTarget(
+					fmt.Sprintf("The pattern '%s' could not be compiled.", entry),
+					entry,
+					cfg.Flags.Path)
+			}
+
+			sort.Strings(matches)
+			for _, m := range matches {
+				if !StringInSlice(m, cfg.ReadingOrder) {
+					cfg.ReadingOrder = append(cfg.ReadingOrder, m)
+				}
+			}
+		}
+		return nil
+	},
+	"Lang": func(sec *ini.Section, cfg *Config, args []string) error {
+		cfg.GLang = sec.Key("Lang").MustString(DefaultLang)
+		return nil
+	},
 	"LTPath": func(sec *ini.Section, cfg *Config, args []string) error {
 		cfg.LTPath = sec.Key("LTPath").String()
 		return nil
 	},
+	"LTLanguage": func(sec *ini.Section, cfg *Config, args []string) error {
+		cfg.LTOptions.Language = sec.Key("LTLanguage").String()
+		return nil
+	},
+	"LTMotherTongue": func(sec *ini.Section, cfg *Config, args []string) error {
+		cfg.LTOptions.MotherTongue = sec.Key("LTMotherTongue").String()
+		return nil
+	},
+	"LTEnabledRules": func(sec *ini.Section, cfg *Config, args []string) error {
+		cfg.LTOptions.EnabledRules = mergeValues(sec.Key("LTEnabledRules").StringsWithShadows(","))
+		return nil
+	},
+	"LTDisabledRules": func(sec *ini.Section, cfg *Config, args []string) error {
+		cfg.LTOptions.DisabledRules = mergeValues(sec.Key("LTDisabledRules").StringsWithShadows(","))
+		return nil
+	},
+	"LTEnabledCategories": func(sec *ini.Section, cfg *Config, args []string) error {
+		cfg.LTOptions.EnabledCategories = mergeValues(sec.Key("LTEnabledCategories").StringsWithShadows(","))
+		return nil
+	},
+	"LTDisabledCategories": func(sec *ini.Section, cfg *Config, args []string) error {
+		cfg.LTOptions.DisabledCategories = mergeValues(sec.Key("LTDisabledCategories").StringsWithShadows(","))
+		return nil
+	},
+	"SuggestionBudget": func(sec *ini.Section, cfg *Config, args []string) error {
+		cfg.Budgets["suggestion"] = sec.Key("SuggestionBudget").MustInt(0)
+		return nil
+	},
+	"WarningBudget": func(sec *ini.Section, cfg *Config, args []string) error {
+		cfg.Budgets["warning"] = sec.Key("WarningBudget").MustInt(0)
+		return nil
+	},
+	"LTRetries": func(sec *ini.Section, cfg *Config, args []string) error {
+		cfg.LTOptions.Retries = sec.Key("LTRetries").MustInt(2)
+		return nil
+	},
 	"SphinxBuildPath": func(sec *ini.Section, cfg *Config, args []string) error {
 		canidate := filepath.FromSlash(sec.Key("SphinxBuildPath").MustString(""))
 		cfg.SphinxBuild = determinePath(cfg.Flags.Path, canidate)
@@ -206,6 +317,14 @@ func loadINI(cfg *Config) error {
 		cfg.MinAlertLevel = LevelToInt[cfg.Flags.AlertLevel]
 	}
 
+	if FileExists(cfg.Flags.Path) {
+		root := cfg.Flags.Path
+		if !IsDir(root) {
+			root = filepath.Dir(root)
+		}
+		cfg.Root, _ = filepath.Abs(root)
+	}
+
 	uCfg.BlockMode = false
 	return processConfig(uCfg, cfg, sources)
 }
@@ -269,6 +388,21 @@ func processSources(cfg *Config, sources []string) (*ini.File, error) {
 	return uCfg, err
 }
 
+// setTags records the tags listed under `key` as enabled (`on`) or disabled.
+//
+// If a tag is both enabled and disabled, it's disabled.
+func setTags(tags map[string]bool, sec *ini.Section, key string, on bool) map[string]bool {
+	if tags == nil {
+		tags = make(map[string]bool)
+	}
+	for _, tag := range mergeValues(sec.Key(key).StringsWithShadows(",")) {
+		if enabled, found := tags[tag]; !found || enabled {
+			tags[tag] = on
+		}
+	}
+	return tags
+}
+
 func processConfig(uCfg *ini.File, cfg *Config, paths []string) error {
 	core := uCfg.Section("")
 	global := uCfg.Section("*")
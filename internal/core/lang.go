@@ -0,0 +1,114 @@
+package core
+
+import (
+	"strings"
+	"sync"
+
+	"gopkg.in/neurosnap/sentences.v1"
+)
+
+// DefaultLang is the language assigned to files that don't have a `Lang`
+// setting.
+const DefaultLang = "en-US"
+
+// A SentenceSplitter splits text into sentences.
+type SentenceSplitter interface {
+	Tokenize(text string) []string
+}
+
+// langAbbrevs are common abbreviations (in Punkt's lowercase, period-less
+// form) for the non-English languages we know how to split.
+//
+// NOTE: Without these, the (untrained) Punkt tokenizer would treat text like
+// "z.B. das" as two sentences.
+var langAbbrevs = map[string][]string{
+	"de": {
+		"abb", "abs", "bd", "bspw", "bzgl", "bzw", "ca", "d.h", "dr", "evtl",
+		"ggf", "hr", "i.d.r", "inkl", "max", "min", "mio", "mrd", "nr", "o.a",
+		"prof", "s", "sog", "str", "u.a", "u.u", "usw", "vgl", "z.b", "z.t"},
+	"fr": {
+		"av", "bd", "c.-à-d", "cf", "chap", "dr", "etc", "ex", "fig", "m",
+		"me", "mlle", "mme", "n°", "p", "p.ex", "pr", "st", "ste", "vol"},
+	"es": {
+		"av", "dr", "dra", "ej", "etc", "fig", "p.ej", "pág", "sr", "sra",
+		"srta", "ud", "uds", "vol"},
+	"it": {
+		"es", "ecc", "dott", "fig", "ing", "pag", "prof", "sig", "sig.ra",
+		"vol"},
+	"nl": {
+		"bijv", "blz", "bv", "d.w.z", "dhr", "dr", "e.d", "enz", "fig", "mevr",
+		"nr", "o.a", "prof", "vb"},
+	"pt": {
+		"dr", "dra", "ex", "etc", "fig", "p.ex", "pág", "prof", "sr", "sra",
+		"vol"},
+}
+
+var (
+	splitterMu sync.Mutex
+	splitters  = map[string]SentenceSplitter{}
+)
+
+type punktSplitter struct {
+	tokenizer *sentences.DefaultSentenceTokenizer
+}
+
+func (p punktSplitter) Tokenize(text string) []string {
+	sents := []string{}
+	for _, s := range p.tokenizer.Tokenize(text) {
+		sents = append(sents, s.Text)
+	}
+	return sents
+}
+
+// SentenceTokenizerFor returns a sentence tokenizer for the given language.
+//
+// English (and any language we don't have specific support for) uses
+// `SentenceTokenizer`.
+func SentenceTokenizerFor(lang string) SentenceSplitter {
+	code := BaseLang(lang)
+
+	abbrevs, found := langAbbrevs[code]
+	if !found {
+		return SentenceTokenizer
+	}
+
+	splitterMu.Lock()
+	defer splitterMu.Unlock()
+
+	if s, ok := splitters[code]; ok {
+		return s
+	}
+
+	storage := sentences.NewStorage()
+	for _, abbr := range abbrevs {
+		storage.AbbrevTypes.Add(abbr)
+	}
+
+	s := punktSplitter{tokenizer: sentences.NewSentenceTokenizer(storage)}
+	splitters[code] = s
+
+	return s
+}
+
+// BaseLang returns the lowercase language subtag of `lang` -- e.g., "de" for
+// "de-CH".
+func BaseLang(lang string) string {
+	lang = strings.ToLower(strings.Replace(lang, "_", "-", -1))
+	return strings.SplitN(lang, "-", 2)[0]
+}
+
+// MatchesLang determines if a rule written for `want` applies to a file
+// written in `have`.
+//
+// An empty `want` matches everything, while a bare language (e.g., "de")
+// matches all of its regional variants (e.g., "de-DE" and "de-CH").
+func MatchesLang(want, have string) bool {
+	if want == "" {
+		return true
+	}
+
+	want = strings.ToLower(strings.Replace(want, "_", "-", -1))
+	have = strings.ToLower(strings.Replace(have, "_", "-", -1))
+
+	return want == have || strings.HasPrefix(have, want+"-")
+}
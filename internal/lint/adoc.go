@@ -6,9 +6,12 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/adoc"
 	"github.com/jdkato/regexp"
 )
 
@@ -84,31 +87,95 @@ end`
 
 func (l *Linter) lintADoc(f *core.File) error {
 	var html string
+	var includes []adoc.Include
 	var err error
 
+	s, err := l.prep(f.Content, "\n----\n$1\n----\n", "`$1`", ".adoc")
+	if err != nil {
+		return err
+	}
+
 	exe := core.Which([]string{"asciidoctor"})
 	if exe == "" {
-		return core.NewE100("lintAdoc", errors.New("asciidoctor not found"))
+		// Asciidoctor isn't available, so we use our own (pure-Go) parser.
+		html, includes = adoc.ToHTML(s, filepath.Dir(f.Path))
+	} else {
+		s = adocSanitizer.Replace(s)
+		if err := l.startAdocServer(exe); err != nil {
+			html, err = callAdoc(f, s, exe)
+		} else {
+			html, err = l.post(f, s, adocURL)
+		}
+
+		if err != nil {
+			return core.NewE100(f.Path, err)
+		}
+
+		html = adocSanitizer.Replace(html)
 	}
 
-	s, err := l.prep(f.Content, "\n----\n$1\n----\n", "`$1`", ".adoc")
-	if err != nil {
+	f.Content = adocBody(f.Content)
+	if err = l.lintHTMLTokens(f, []byte(html), 0); err != nil {
 		return err
 	}
 
-	s = adocSanitizer.Replace(s)
-	if err := l.startAdocServer(exe); err != nil {
-		html, err = callAdoc(f, s, exe)
-	} else {
-		html, err = l.post(f, s, adocURL)
+	return l.lintIncludes(f, includes)
+}
+
+// lintIncludes lints the files included by `f`, each of which is linted as
+// its own document.
+//
+// Their alerts are reported at the line of the `include::` directive that
+// includes them, with their location in the included file recorded in the
+// alert's `Include`.
+func (l *Linter) lintIncludes(f *core.File, includes []adoc.Include) error {
+	from := 0
+	for _, inc := range includes {
+		var line int
+		var span []int
+
+		line, span, from = findDirective(f.Lines, "include::"+inc.Target+"[", from)
+
+		sub := f.Fork(adocBody(inc.Source), ".adoc", ".adoc", "markup")
+		sub.Lines = strings.SplitAfter(inc.Source, "\n")
+		if err := l.lintHTMLTokens(sub, []byte(inc.HTML), 0); err != nil {
+			return err
+		} else if err = l.lintIncludes(sub, inc.Includes); err != nil {
+			return err
+		}
+
+		for _, a := range sub.Alerts {
+			if a.Include == nil {
+				a.Include = &core.Include{Path: inc.Path, Line: a.Line, Span: a.Span}
+			}
+			a.Line, a.Span = line, span
+			f.AddLocatedAlert(a)
+		}
+		for _, e := range sub.Errors {
+			f.AddError(e.Check, errors.New(e.Message))
+		}
 	}
 
-	if err != nil {
-		return core.NewE100(f.Path, err)
+	return nil
+}
+
+// findDirective returns the line and span of the first occurrence of
+// `directive` in `lines` at or after the (0-based) line `from`, along with
+// the line to continue searching from.
+func findDirective(lines []string, directive string, from int) (int, []int, int) {
+	for i := from; i < len(lines); i++ {
+		if idx := strings.Index(lines[i], directive); idx >= 0 {
+			col := utf8.RuneCountInString(lines[i][:idx]) + 1
+			end := utf8.RuneCountInString(strings.TrimRight(lines[i], "\r\n"))
+			return i + 1, []int{col, end}, i + 1
+		}
 	}
+	return 1, []int{1, 1}, from
+}
 
-	html = adocSanitizer.Replace(html)
-	body := reSource.ReplaceAllStringFunc(f.Content, func(m string) string {
+// adocBody prepares the AsciiDoc `content` for locating alerts.
+func adocBody(content string) string {
+	return reSource.ReplaceAllStringFunc(content, func(m string) string {
 		// NOTE: This is required to avoid finding matches in block attributes.
 		//
 		// See https://github.com/errata-ai/vale/issues/296.
@@ -116,9 +183,6 @@ func (l *Linter) lintADoc(f *core.File) error {
 		span := strings.Repeat("*", len(parts[len(parts)-1])-2)
 		return "[source, " + span + "]"
 	})
-
-	f.Content = body
-	return l.lintHTMLTokens(f, []byte(html), 0)
 }
 
 func (l *Linter) startAdocServer(exe string) error {
@@ -2,6 +2,7 @@ package lint
 
 import (
 	"bytes"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -37,6 +38,13 @@ var tagToScope = map[string]string{
 }
 
 func (l Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error {
+	l.lintDocument(f, l.lintHTMLBlocks(f, raw, offset))
+	return nil
+}
+
+// lintHTMLBlocks lints each block of the HTML `raw` (converted from `f`) and
+// returns the IDs of its elements.
+func (l Linter) lintHTMLBlocks(f *core.File, raw []byte, offset int) map[string]bool {
 	var class, attr string
 	var inBlock, inline, skip, skipClass bool
 
@@ -55,9 +63,22 @@ func (l Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error {
 		skipped = l.Manager.Config.IgnoredScopes
 	}
 
+	outline := outliner{}
+	links := linkCollector{}
+
 	walker := newWalker(f, raw, offset)
 	for {
 		tokt, tok, txt := walker.walk()
+		if tokt == html.StartTagToken && len(walker.queue) == 0 {
+			// NOTE: Some of our converters (e.g., reStructuredText) record
+			// the source line of each block.
+			if line, err := strconv.Atoi(getAttribute(tok, "data-line")); err == nil {
+				walker.seek(line - 1)
+			}
+		}
+		outline.visit(f, tokt, tok, &walker)
+		links.visit(f, tokt, tok, &walker)
+
 		skipClass = checkClasses(class, skipClasses)
 		if tokt == html.ErrorToken {
 			break
@@ -114,29 +135,59 @@ func (l Linter) lintHTMLTokens(f *core.File, raw []byte, offset int) error {
 		l.lintTags(f, walker, tok)
 	}
 
+	return links.ids
+}
+
+// lintDocument applies the rules that see a document as a whole -- i.e.,
+// those that check its links or use the `summary` and `raw` scopes.
+func (l Linter) lintDocument(f *core.File, ids map[string]bool) {
+	if l.needsLinks() {
+		headings := []string{}
+		for _, sec := range f.Outline {
+			headings = append(headings, sec.Text)
+		}
+
+		f.IDs = sectionIDs(f.NormedExt, strings.Join(f.Lines, ""), headings)
+		for id := range ids {
+			f.IDs[id] = true
+		}
+		l.resolveLinks(f)
+	}
 	l.lintSizedScopes(f)
-	return nil
 }
 
 func (l Linter) lintScope(f *core.File, state walker, txt string) {
+	var scope string
 	for _, tag := range state.tagHistory {
-		scope, match := tagToScope[tag]
-		if (match && !core.StringInSlice(tag, inlineTags)) || heading.MatchString(tag) {
-			if match {
-				scope = scope + f.RealExt
-			} else {
-				scope = "text.heading." + tag + f.RealExt
-			}
-			txt = strings.TrimLeft(txt, " ")
-			b := state.block(txt, scope)
-			l.lintBlock(f, b, state.lines, 0, false)
-			return
+		sec, match := tagToScope[tag]
+		if match && core.StringInSlice(tag, inlineTags) {
+			continue
+		} else if !match && heading.MatchString(tag) {
+			sec, match = "text.heading."+tag, true
 		}
+
+		if !match {
+			continue
+		} else if scope == "" {
+			scope = sec
+		} else {
+			// A nested structure (e.g., a list inside of a table cell)
+			// includes the sections of its parents.
+			scope += strings.TrimPrefix(sec, "text")
+		}
+	}
+
+	if scope != "" {
+		txt = strings.TrimLeft(txt, " ")
+		f.AddProse(txt, state.firstLine(), false)
+		b := state.block(txt, scope+f.RealExt)
+		l.lintBlock(f, b, state.lines, 0, false)
+		return
 	}
 
 	// NOTE: We don't include headings, list items, or table cells (which are
 	// processed above) in our Summary content.
-	f.Summary.WriteString(txt + " ")
+	f.AddProse(txt, state.firstLine(), true)
 
 	b := state.block(txt, "txt")
 	l.lintProse(f, b, state.lines)
@@ -0,0 +1,52 @@
+package lint
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/commit"
+)
+
+// lintCommit lints a Git commit message.
+//
+// The subject, body, and trailer are linted separately, using the
+// `text.subject`, `text.body`, and `text.trailer` scopes; comments and
+// anything below the scissors line (e.g., the diff shown by `git commit
+// --verbose`) are ignored.
+//
+// Messages are read from a `.commit` file, a file that Git writes a message
+// to (e.g., `.git/COMMIT_EDITMSG`), or stdin with `--ext=.commit`. So, a
+// `commit-msg` hook can simply run `vale "$1"`.
+func (l Linter) lintCommit(f *core.File) {
+	msg := commit.Parse(f.Content)
+
+	l.lintCommitPart(f, msg.Subject, "text.subject", true)
+	l.lintCommitPart(f, msg.Body, "text.body", true)
+	l.lintCommitPart(f, msg.Trailer, "text.trailer", false)
+}
+
+// lintCommitPart lints a part of a commit message -- as prose (i.e.,
+// including its sentences and paragraphs) or, if `prose` is false, as a
+// single block -- and then maps its alerts to their lines in the message.
+func (l Linter) lintCommitPart(f *core.File, p commit.Part, scope string, prose bool) {
+	if strings.TrimSpace(p.Text) == "" {
+		return
+	}
+
+	sub := f.Fork(p.Text, f.RealExt, f.NormedExt, f.Format)
+	blk := core.NewLinedBlock(p.Text, p.Text, scope+f.RealExt, 0)
+	if prose {
+		l.lintScopedProse(sub, blk, scope, len(sub.Lines))
+	} else {
+		l.lintBlock(sub, blk, len(sub.Lines), 0, strings.Contains(p.Text, "\n"))
+	}
+
+	for _, a := range sub.Alerts {
+		a.Line = p.Locate(a.Line)
+		f.AddLocatedAlert(a)
+	}
+	for _, err := range sub.Errors {
+		f.AddError(err.Check, errors.New(err.Message))
+	}
+}
@@ -0,0 +1,57 @@
+package lint
+
+import (
+	"errors"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/data"
+)
+
+// lintData lints the string values of a JSON, YAML, or TOML file that are
+// selected by its `LintKeys`.
+//
+// Each value is linted as a separate block of text, and its alerts are then
+// mapped to their location in the file. Without any `LintKeys`, the file is
+// linted as plain text.
+func (l Linter) lintData(f *core.File) error {
+	if len(f.LintKeys) == 0 {
+		l.lintLines(f)
+		return nil
+	}
+
+	values, err := data.Parse(f.Content, f.NormedExt)
+	if err != nil {
+		return core.NewE100(f.Path, err)
+	}
+
+	for _, v := range values {
+		if v.Text == "" || !selected(f.LintKeys, v.Path) {
+			continue
+		}
+
+		sub := f.Fork(v.Text, f.RealExt, f.NormedExt, f.Format)
+		l.lintProse(sub, core.NewLinedBlock(v.Text, v.Text, "text"+f.RealExt, 0), len(sub.Lines))
+
+		for _, a := range sub.Alerts {
+			line, start := v.Locate(a.Line, a.Span[0])
+			_, end := v.Locate(a.Line, a.Span[1])
+			a.Line, a.Span = line, []int{start, end}
+			f.AddLocatedAlert(a)
+		}
+		for _, e := range sub.Errors {
+			f.AddError(e.Check, errors.New(e.Message))
+		}
+	}
+
+	return nil
+}
+
+// selected reports whether `path` matches one of `patterns`.
+func selected(patterns, path []string) bool {
+	for _, pattern := range patterns {
+		if data.Match(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
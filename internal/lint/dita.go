@@ -2,7 +2,6 @@ package lint
 
 import (
 	"bytes"
-	"errors"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -18,8 +17,10 @@ func (l Linter) lintDITA(file *core.File) error {
 	var htmlFile string
 
 	dita := core.Which([]string{"dita", "dita.bat"})
-	if dita == "" {
-		return core.NewE100("lintDITA", errors.New("dita not found"))
+	if dita == "" || len(file.XMLScopes) > 0 {
+		// NOTE: DITA-OT is slow (see below), so we only use it when it's
+		// available and no scopes have been configured.
+		return l.lintXMLScopes(file, "dita")
 	}
 
 	tempDir, err := ioutil.TempDir("", "dita-")
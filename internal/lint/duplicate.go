@@ -0,0 +1,165 @@
+package lint
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/errata-ai/vale/v2/internal/check"
+	"github.com/errata-ai/vale/v2/internal/core"
+)
+
+// bandRows is the number of signature rows per LSH band: two blocks are
+// only compared if they share all of the rows in at least one band.
+const bandRows = 2
+
+// fingerprint is a block recorded by a `duplicate`-based rule.
+type fingerprint struct {
+	file  *core.File
+	index int // the position of the block's alert in `file.Pending`
+}
+
+func (fp fingerprint) alert() core.Alert {
+	return fp.file.Pending[fp.index]
+}
+
+// lintDuplicates applies the results of any `duplicate`-based rules.
+//
+// Each rule records a pending alert (with a MinHash signature) for every
+// block it sees; we then report only those blocks that are similar to
+// another one, in the same file or -- for `project` rules -- any file.
+func (l *Linter) lintDuplicates(linted []*core.File) {
+	files := make([]*core.File, len(linted))
+	copy(files, linted)
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	byCheck := make(map[string][]fingerprint)
+	for _, f := range files {
+		for i, a := range f.Pending {
+			if len(a.Signature) > 0 {
+				byCheck[a.Check] = append(byCheck[a.Check], fingerprint{f, i})
+			}
+		}
+	}
+
+	if len(byCheck) == 0 {
+		return
+	}
+
+	messages := make(map[*core.File]map[int][2]string)
+	for name, fps := range byCheck {
+		rule, ok := l.Manager.Rules()[name].(check.Duplicate)
+		if !ok {
+			continue
+		}
+		sortFingerprints(fps)
+
+		found := findDuplicates(fps, rule)
+
+		later := make([]int, 0, len(found))
+		for i := range found {
+			later = append(later, i)
+		}
+		sort.Ints(later)
+
+		for _, i := range later {
+			j := found[i]
+			// We report both blocks: the later one, which refers to the
+			// earlier one, ...
+			addDuplicate(messages, fps[i], fps[j])
+			// ... and the earlier one (unless it's a duplicate itself), which
+			// refers to its first duplicate.
+			if _, dup := found[j]; !dup {
+				if _, seen := messages[fps[j].file][fps[j].index]; !seen {
+					addDuplicate(messages, fps[j], fps[i])
+				}
+			}
+		}
+	}
+
+	for _, f := range linted {
+		pending := f.Pending
+		f.Pending = nil
+		for i, a := range pending {
+			if msg, found := messages[f][i]; found {
+				a.Message, a.Description = msg[0], msg[1]
+				a.Signature = nil
+				f.AddLocatedAlert(a)
+			}
+		}
+	}
+}
+
+// addDuplicate records the message of `fp`'s alert, which refers to the
+// location of the similar block `other`.
+func addDuplicate(messages map[*core.File]map[int][2]string, fp, other fingerprint) {
+	where := fmt.Sprintf("line %d", other.alert().Line)
+	if fp.file != other.file {
+		where = fmt.Sprintf("%s:%d", other.file.Path, other.alert().Line)
+	}
+
+	sim := fmt.Sprintf("%.0f%%", 100*check.Similarity(
+		fp.alert().Signature, other.alert().Signature))
+
+	if messages[fp.file] == nil {
+		messages[fp.file] = make(map[int][2]string)
+	}
+	messages[fp.file][fp.index] = [2]string{
+		core.FormatMessage(fp.alert().Message, where, sim),
+		core.FormatMessage(fp.alert().Description, where, sim)}
+}
+
+// findDuplicates maps the index of each fingerprint that's similar to an
+// earlier one to the index of the most similar earlier fingerprint.
+func findDuplicates(fps []fingerprint, rule check.Duplicate) map[int]int {
+	found := make(map[int]int)
+	best := make(map[int]float64)
+
+	buf := make([]byte, 8)
+	buckets := make(map[[2]uint64][]int)
+	for i, fp := range fps {
+		sig := fp.alert().Signature
+		seen := make(map[int]bool)
+
+		for band := 0; band+bandRows <= len(sig); band += bandRows {
+			h := fnv.New64a()
+			for _, v := range sig[band : band+bandRows] {
+				binary.LittleEndian.PutUint64(buf, v)
+				h.Write(buf)
+			}
+			key := [2]uint64{uint64(band), h.Sum64()}
+
+			for _, j := range buckets[key] {
+				if seen[j] || (!rule.Project && fps[j].file != fp.file) {
+					continue
+				}
+				seen[j] = true
+
+				sim := check.Similarity(sig, fps[j].alert().Signature)
+				if _, ok := found[i]; sim < rule.Threshold || (ok && sim < best[i]) {
+					continue
+				} else if !ok || sim > best[i] || j < found[i] {
+					found[i], best[i] = j, sim
+				}
+			}
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	return found
+}
+
+func sortFingerprints(fps []fingerprint) {
+	sort.SliceStable(fps, func(i, j int) bool {
+		a, b := fps[i].alert(), fps[j].alert()
+		if fps[i].file != fps[j].file {
+			return fps[i].file.Path < fps[j].file.Path
+		} else if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Span[0] < b.Span[0]
+	})
+}
@@ -0,0 +1,163 @@
+package lint
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/ipynb"
+)
+
+// lintNotebook lints a Jupyter notebook: its Markdown cells are linted as
+// Markdown and its code cells are linted as source code in the kernel's
+// language.
+//
+// Each cell's blocks are linted separately, but its Markdown cells are
+// treated as a single document by rules that apply to a document as a whole
+// (e.g., those that use the `summary` scope). All alerts are then mapped to
+// their location in the notebook's JSON.
+func (l Linter) lintNotebook(f *core.File) error {
+	nb, err := ipynb.Parse([]byte(f.Content))
+	if err != nil {
+		return core.NewE100(f.Path, err)
+	}
+
+	ext := nb.Ext()
+	normed, format := core.FormatFromExt(ext, l.Manager.Config.Formats)
+
+	doc := notebookDoc{ids: make(map[string]bool)}
+	for i, cell := range nb.Cells {
+		var sub *core.File
+
+		switch cell.Type {
+		case "markdown":
+			var ids map[string]bool
+
+			sub = f.Fork(cell.Source, ".md", ".md", "markup")
+			if ids, err = l.lintMarkdownBlocks(sub); err == nil {
+				doc.add(sub, i+1, ids)
+			}
+		case "code":
+			if format != "code" {
+				continue
+			}
+			sub = f.Fork(cell.Source, ext, normed, format)
+			l.lintCode(sub)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for _, a := range sub.Alerts {
+			f.AddLocatedAlert(locate(a, i+1, cell.Lines))
+		}
+		for _, e := range sub.Errors {
+			f.AddError(e.Check, errors.New(e.Message))
+		}
+	}
+
+	whole := f.Fork(doc.content.String(), ".md", ".md", "markup")
+	whole.Outline, whole.Links = doc.outline, doc.links
+	whole.Summary.WriteString(doc.summary.String())
+	whole.Prose.WriteString(doc.prose.String())
+	whole.Segments = doc.segments
+
+	l.lintDocument(whole, doc.ids)
+	f.IDs = whole.IDs
+	for _, a := range whole.Alerts {
+		if a.Line < 1 || a.Line > len(doc.lines) {
+			f.AddLocatedAlert(a)
+			continue
+		}
+		at := doc.lines[a.Line-1]
+		a.Line = at.line
+		f.AddLocatedAlert(locate(a, at.cell, nb.Cells[at.cell-1].Lines))
+	}
+	for _, e := range whole.Errors {
+		f.AddError(e.Check, errors.New(e.Message))
+	}
+	f.Outline, f.Links = whole.Outline, whole.Links
+
+	return nil
+}
+
+// notebookDoc is the document formed by a notebook's Markdown cells.
+type notebookDoc struct {
+	content  strings.Builder
+	summary  strings.Builder
+	prose    strings.Builder
+	segments []core.Segment
+	outline  []core.Section
+	links    []core.Hyperlink
+	ids      map[string]bool
+
+	// lines maps each line of `content` to its cell and its line in the cell.
+	lines []struct{ cell, line int }
+}
+
+// add appends the (already linted) `n`th cell, `sub`, to the document.
+func (d *notebookDoc) add(sub *core.File, n int, ids map[string]bool) {
+	// The number of lines that precede the cell in the document.
+	base := len(d.lines)
+
+	for _, seg := range sub.Segments {
+		d.segments = append(d.segments, core.Segment{
+			Offset: seg.Offset + utf8.RuneCountInString(d.prose.String()),
+			Line:   seg.Line + base})
+	}
+
+	content := sub.Content
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	d.content.WriteString(content)
+	for i := 0; i < strings.Count(content, "\n"); i++ {
+		d.lines = append(d.lines, struct{ cell, line int }{n, i + 1})
+	}
+
+	outline := sub.Outline
+	if len(outline) > 0 && outline[0].Level == 0 && len(d.outline) > 0 {
+		// The cell's content before its first heading belongs to the
+		// previous cell's last section.
+		last := &d.outline[len(d.outline)-1]
+		last.Blocks = append(last.Blocks, outline[0].Blocks...)
+		outline = outline[1:]
+	}
+	for _, sec := range outline {
+		if sec.Line > 0 {
+			sec.Line += base
+		}
+		d.outline = append(d.outline, sec)
+	}
+
+	d.summary.WriteString(sub.Summary.String())
+	d.prose.WriteString(sub.Prose.String())
+	for _, link := range sub.Links {
+		if link.Line > 0 {
+			link.Line += base
+		}
+		d.links = append(d.links, link)
+	}
+	for id := range ids {
+		d.ids[id] = true
+	}
+}
+
+// locate maps an alert from the `n`th cell of a notebook to its location in
+// the notebook's JSON.
+func locate(a core.Alert, n int, lines []ipynb.Loc) core.Alert {
+	a.Cell = &core.Cell{Index: n, Line: a.Line, Span: a.Span}
+	if a.Line < 1 || a.Line > len(lines) {
+		return a
+	}
+
+	loc := lines[a.Line-1]
+	a.Line = loc.Line
+	a.Span = []int{loc.Col(a.Span[0]), loc.Col(a.Span[1])}
+
+	return a
+}
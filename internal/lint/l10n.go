@@ -0,0 +1,63 @@
+package lint
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/l10n"
+)
+
+func (l Linter) lintPO(f *core.File) error {
+	entries, err := l10n.ParsePO(f.Content)
+	if err != nil {
+		return core.NewE100(f.Path, err)
+	}
+	l.lintEntries(f, entries)
+	return nil
+}
+
+func (l Linter) lintXLIFF(f *core.File) error {
+	entries, err := l10n.ParseXLIFF(f.Content)
+	if err != nil {
+		return core.NewE100(f.Path, err)
+	}
+	l.lintEntries(f, entries)
+	return nil
+}
+
+// lintEntries lints the target (or, if `LintSource` is set, the source) text
+// of each localization entry.
+//
+// Each text is linted as a separate block in its own language, and its
+// alerts are then mapped to their location in the file.
+func (l Linter) lintEntries(f *core.File, entries []l10n.Entry) {
+	for _, e := range entries {
+		texts := e.Target
+		if f.LintSource {
+			texts = e.Source
+		}
+
+		for _, t := range texts {
+			if strings.TrimSpace(t.Content) == "" {
+				continue
+			}
+
+			sub := f.Fork(t.Content, f.RealExt, f.NormedExt, f.Format)
+			if t.Lang != "" {
+				sub.Lang = t.Lang
+			}
+			l.lintProse(sub, core.NewLinedBlock(t.Content, t.Content, "text"+f.RealExt, 0), len(sub.Lines))
+
+			for _, a := range sub.Alerts {
+				line, start := t.Locate(a.Line, a.Span[0])
+				_, end := t.Locate(a.Line, a.Span[1])
+				a.Line, a.Span, a.Entry = line, []int{start, end}, e.ID
+				f.AddLocatedAlert(a)
+			}
+			for _, err := range sub.Errors {
+				f.AddError(err.Check, errors.New(err.Message))
+			}
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package lint
+
+import (
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/latex"
+)
+
+func (l Linter) lintLaTeX(f *core.File) error {
+	s, err := l.prep(
+		f.Content, "\n\\begin{verbatim}\n$1\n\\end{verbatim}\n", "\\verb|$1|", ".tex")
+	if err != nil {
+		return err
+	}
+
+	// NOTE: See `lintMDX` for why we convert the original content too.
+	f.Content = latex.Convert(f.Content, f.Macros).Masked
+	return l.lintHTMLTokens(f, []byte(latex.Convert(s, f.Macros).HTML), 0)
+}
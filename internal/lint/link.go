@@ -0,0 +1,388 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/adoc"
+	"github.com/errata-ai/vale/v2/pkg/latex"
+	"github.com/errata-ai/vale/v2/pkg/mdx"
+	"github.com/errata-ai/vale/v2/pkg/org"
+	"github.com/errata-ai/vale/v2/pkg/rst"
+	"github.com/jdkato/regexp"
+	"golang.org/x/net/html"
+)
+
+// linkExts are the extensions we try when a link's target doesn't exist as
+// written -- e.g., a link to `install.html` that's built from `install.md`.
+var linkExts = []string{".md", ".mdx", ".rst", ".adoc", ".html"}
+
+// linkCollector records the links and element IDs seen by `lintHTMLTokens`.
+type linkCollector struct {
+	inLink bool
+	href   string
+	text   strings.Builder
+
+	// ctx is the walker's context at the start of the current link, with
+	// the block's preceding text masked.
+	ctx string
+
+	ids map[string]bool
+}
+
+func (c *linkCollector) visit(f *core.File, tokt html.TokenType, tok html.Token, w *walker) {
+	switch tokt {
+	case html.StartTagToken, html.SelfClosingTagToken:
+		for _, a := range tok.Attr {
+			if a.Key == "id" || (a.Key == "name" && tok.Data == "a") {
+				if c.ids == nil {
+					c.ids = make(map[string]bool)
+				}
+				c.ids[a.Val] = true
+			}
+		}
+		if tok.Data == "a" && tokt == html.StartTagToken {
+			c.href = getAttribute(tok, "href")
+			c.inLink = c.href != ""
+			c.text.Reset()
+			if c.inLink {
+				c.ctx = updateContext(w.context, w.queue)
+			}
+		}
+	case html.EndTagToken:
+		if tok.Data == "a" && c.inLink {
+			link := core.Hyperlink{
+				Href: c.href, Text: strings.TrimSpace(c.text.String())}
+
+			match := link.Text
+			if match == "" {
+				match = link.Href
+			}
+			link.Line, link.Span = locateLink(c.ctx, match)
+
+			f.Links = append(f.Links, link)
+			c.inLink = false
+		}
+	case html.TextToken:
+		if c.inLink {
+			c.text.WriteString(tok.Data)
+		}
+	}
+}
+
+// locateLink finds the line and span of a link's text, `s`, in `ctx`.
+//
+// It returns a line of 0 if the text can't be found.
+func locateLink(ctx, s string) (int, []int) {
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		pos := strings.Index(ctx, line)
+		if pos < 0 {
+			// The text may include inline markup (e.g., `**bold**`).
+			line = strings.Fields(line)[0]
+			if pos = strings.Index(ctx, line); pos < 0 {
+				return 0, nil
+			}
+		}
+
+		start := strings.LastIndex(ctx[:pos], "\n") + 1
+		col := utf8.RuneCountInString(ctx[start:pos]) + 1
+		return strings.Count(ctx[:pos], "\n") + 1,
+			[]int{col, col + utf8.RuneCountInString(line) - 1}
+	}
+	return 0, nil
+}
+
+// needsLinks determines if any of our rules extend `link`.
+func (l Linter) needsLinks() bool {
+	for _, chk := range l.Manager.Rules() {
+		if chk.Fields().Extends == "link" {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveLinks determines which of `f`'s links are broken.
+//
+// Local links are resolved against the filesystem (relative to `f` or, for
+// root-relative links, the configuration file) and their anchors against
+// the IDs of `f` or, for links to other files, those of the target document
+// (see `lintLinks`).
+func (l Linter) resolveLinks(f *core.File) {
+	root := l.Manager.Config.Root
+	if root == "" {
+		root, _ = os.Getwd()
+	}
+
+	for i, link := range f.Links {
+		u, err := url.Parse(link.Href)
+		if err != nil {
+			f.Links[i].Broken = "file"
+			continue
+		} else if u.Scheme != "" || u.Host != "" {
+			f.Links[i].External = true
+			continue
+		}
+		f.Links[i].Anchor = u.Fragment
+
+		if u.Path == "" {
+			if u.Fragment != "" && !f.IDs[u.Fragment] {
+				f.Links[i].Broken = "anchor"
+			}
+			continue
+		}
+
+		target := ""
+		if strings.HasPrefix(u.Path, "/") {
+			target = filepath.Join(root, filepath.FromSlash(u.Path))
+		} else {
+			target = filepath.Join(
+				filepath.Dir(f.Path), filepath.FromSlash(u.Path))
+		}
+
+		target = findTarget(target, f.RealExt)
+		if target == "" {
+			f.Links[i].Broken = "file"
+		} else if u.Fragment != "" {
+			f.Links[i].Target = target
+		}
+	}
+}
+
+// lintLinks resolves the anchors of links to other files, which we can only
+// do once all of them have been linted.
+//
+// An anchor is looked up in the IDs of its target if we've linted it and,
+// otherwise, in those we can collect from the file itself. If we can't find
+// the target's IDs at all (e.g., it's a plain-text file), we report the
+// anchor as unverifiable rather than assume that it exists.
+func (l *Linter) lintLinks(linted []*core.File) {
+	ids := make(map[string]map[string]bool)
+	for _, f := range linted {
+		ids[linkKey(f.Path)] = f.IDs
+	}
+
+	for _, f := range linted {
+		pending := f.Pending
+		f.Pending = nil
+		for _, a := range pending {
+			if a.Target == "" {
+				f.Pending = append(f.Pending, a)
+				continue
+			}
+
+			key := linkKey(a.Target)
+			anchors, found := ids[key]
+			if !found {
+				anchors = collectIDs(a.Target)
+				ids[key] = anchors
+			}
+
+			if anchors == nil {
+				a.Severity, a.Description = "suggestion", ""
+				a.Message = fmt.Sprintf(
+					"Unable to verify the anchor '#%s': '%s' has no known IDs.",
+					a.Anchor, filepath.Base(a.Target))
+			} else if anchors[a.Anchor] {
+				continue
+			}
+
+			a.Target, a.Anchor = "", ""
+			f.AddLocatedAlert(a)
+		}
+	}
+}
+
+// linkKey normalizes `path` so that the same file is always found under the
+// same key, however it was linked to (or linted).
+func linkKey(path string) string {
+	abs, err := filepath.Abs(filepath.FromSlash(path))
+	if err != nil {
+		return path
+	}
+	return abs
+}
+
+func findTarget(target, ext string) string {
+	if core.FileExists(target) {
+		return target
+	}
+
+	base := strings.TrimSuffix(target, filepath.Ext(target))
+	for _, e := range append([]string{ext}, linkExts...) {
+		if core.FileExists(base + e) {
+			return base + e
+		}
+	}
+
+	return ""
+}
+
+// collectIDs finds the element IDs of the markup file at `path`.
+//
+// It returns nil if the file isn't in a format whose IDs we know how to find.
+func collectIDs(path string) map[string]bool {
+	var buf bytes.Buffer
+
+	if core.IsDir(path) {
+		return nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	src := core.Sanitize(string(b))
+
+	ext, _ := core.FormatFromExt(path, nil)
+	switch ext {
+	case ".md":
+		if goldMd.Convert([]byte(src), &buf) != nil {
+			return nil
+		}
+	case ".mdx":
+		doc := mdx.Convert(src, nil)
+		if goldMd.Convert([]byte(doc.Markdown), &buf) != nil {
+			return nil
+		}
+	case ".html":
+		buf.WriteString(src)
+	case ".rst":
+		buf.WriteString(rst.ToHTML(src))
+	case ".adoc":
+		doc, _ := adoc.ToHTML(src, filepath.Dir(path))
+		buf.WriteString(doc)
+	case ".org":
+		buf.WriteString(org.Convert(src).HTML)
+	case ".tex":
+		buf.WriteString(latex.Convert(src, nil).HTML)
+	default:
+		return nil
+	}
+
+	ids := map[string]bool{}
+	headings := []string{}
+
+	inHeading := false
+	z := html.NewTokenizer(&buf)
+	for {
+		tokt := z.Next()
+		if tokt == html.ErrorToken {
+			break
+		}
+		tok := z.Token()
+		for _, a := range tok.Attr {
+			if a.Key == "id" || (a.Key == "name" && tok.Data == "a") {
+				ids[a.Val] = true
+			}
+		}
+
+		switch tokt {
+		case html.StartTagToken:
+			if heading.MatchString(tok.Data) {
+				inHeading = true
+				headings = append(headings, "")
+			}
+		case html.EndTagToken:
+			if heading.MatchString(tok.Data) {
+				inHeading = false
+			}
+		case html.TextToken:
+			if inHeading {
+				headings[len(headings)-1] += tok.Data
+			}
+		}
+	}
+
+	for id := range sectionIDs(ext, src, headings) {
+		ids[id] = true
+	}
+	return ids
+}
+
+// sectionIDs returns the IDs that the usual converter for `ext` (e.g.,
+// docutils for reStructuredText) gives to the `headings` and explicit
+// anchors of `src`, since our own HTML for these formats doesn't include
+// them.
+func sectionIDs(ext, src string, headings []string) map[string]bool {
+	ids := map[string]bool{}
+	add := func(id string) {
+		if id != "" {
+			ids[id] = true
+		}
+	}
+
+	switch ext {
+	case ".rst":
+		for _, h := range headings {
+			add(rstID(h))
+		}
+		for _, m := range rstTargetRe.FindAllStringSubmatch(src, -1) {
+			add(rstID(m[1]))
+		}
+	case ".adoc":
+		for _, h := range headings {
+			add(adocID(h))
+		}
+		for _, m := range adocAnchorRe.FindAllStringSubmatch(src, -1) {
+			add(m[1] + m[2] + m[3])
+		}
+	case ".org":
+		for _, h := range headings {
+			add(strings.TrimSpace(h))
+		}
+		for _, m := range orgAnchorRe.FindAllStringSubmatch(src, -1) {
+			add(m[1] + m[2] + m[3])
+		}
+	case ".tex":
+		for _, m := range texLabelRe.FindAllStringSubmatch(src, -1) {
+			add(m[1])
+		}
+	}
+
+	return ids
+}
+
+var (
+	// `.. _target:`
+	rstTargetRe = regexp.MustCompile(`(?m)^\.\. _([^:\n]+):`)
+	// `[[id]]`, `[#id]`, or `anchor:id[]`
+	adocAnchorRe = regexp.MustCompile(
+		`\[\[\[?([\w:.-]+)[\],]|\[#([\w:-]+)[\].,%]|anchor:([\w:.-]+)\[`)
+	// `:CUSTOM_ID: id`, `<<target>>`, or `#+NAME: id`
+	orgAnchorRe = regexp.MustCompile(
+		`(?m)^\s*:CUSTOM_ID:\s*(\S+)|<<([^<>\n]+)>>|^\s*#\+(?i:name):\s*(\S+)`)
+	// `\label{id}`
+	texLabelRe = regexp.MustCompile(`\\label\{([^}]+)\}`)
+
+	nonAlnumRe = regexp.MustCompile(`[^a-z0-9]+`)
+	nonWordRe  = regexp.MustCompile(`[^\w\s.-]+`)
+	adocSepRe  = regexp.MustCompile(`[\s.-]+`)
+)
+
+// rstID returns the ID that docutils generates for `s`: it's lowercase and
+// consists of ASCII letters, digits, and single hyphens.
+func rstID(s string) string {
+	id := nonAlnumRe.ReplaceAllString(strings.ToLower(s), "-")
+	return strings.TrimLeft(strings.Trim(id, "-"), "0123456789-")
+}
+
+// adocID returns the ID that Asciidoctor generates for a section titled
+// `s` (using its default `idprefix` and `idseparator`).
+func adocID(s string) string {
+	id := nonWordRe.ReplaceAllString(strings.ToLower(strings.TrimSpace(s)), "")
+	id = adocSepRe.ReplaceAllString(id, "_")
+	return "_" + strings.TrimSuffix(id, "_")
+}
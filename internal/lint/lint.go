@@ -1,6 +1,7 @@
 package lint
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"os"
@@ -22,6 +23,7 @@ type Linter struct {
 	seen map[string]bool
 	glob *glob.Glob
 
+	ctx    context.Context
 	client *http.Client
 	pids   []int
 	temps  []*os.File
@@ -40,17 +42,38 @@ func NewLinter(cfg *core.Config) (*Linter, error) {
 
 	globalStyles := len(cfg.GBaseStyles)
 	globalChecks := len(cfg.GChecks)
+	globalTags := len(cfg.GTags)
 
 	return &Linter{
 		Manager: mgr,
 
 		client:    http.DefaultClient,
-		nonGlobal: globalStyles+globalChecks == 0}, err
+		nonGlobal: globalStyles+globalChecks+globalTags == 0}, err
+}
+
+// WithContext returns a shallow copy of `l` whose rules are run with `ctx`,
+// which allows callers to cancel (or set a deadline for) a lint run.
+func (l *Linter) WithContext(ctx context.Context) *Linter {
+	l2 := *l
+	l2.ctx = ctx
+	return &l2
+}
+
+func (l Linter) context() context.Context {
+	if l.ctx == nil {
+		return context.Background()
+	}
+	return l.ctx
 }
 
 // LintString src according to its format.
 func (l *Linter) LintString(src string) ([]*core.File, error) {
 	linted := l.lintFile(src)
+	if linted.err == nil {
+		l.lintProject([]*core.File{linted.file})
+		l.lintDuplicates([]*core.File{linted.file})
+		l.lintLinks([]*core.File{linted.file})
+	}
 	return []*core.File{linted.file}, linted.err
 }
 
@@ -75,6 +98,10 @@ func (l *Linter) Lint(input []string, pat string) ([]*core.File, error) {
 		filesChan, errChan := l.lintFiles(done, src)
 
 		for result := range filesChan {
+			if result.err == nil {
+				result.err = l.context().Err()
+			}
+
 			if result.err != nil {
 				l.teardown()
 				return linted, result.err
@@ -91,6 +118,10 @@ func (l *Linter) Lint(input []string, pat string) ([]*core.File, error) {
 	}
 
 	l.teardown()
+	l.lintProject(linted)
+	l.lintDuplicates(linted)
+	l.lintLinks(linted)
+
 	return linted, nil
 }
 
@@ -153,8 +184,9 @@ func (l *Linter) lintFile(src string) lintResult {
 	file, err := core.NewFile(src, l.Manager.Config)
 	if err != nil {
 		return lintResult{err: err}
-	} else if len(file.Checks) == 0 && len(file.BaseStyles) == 0 {
-		if len(l.Manager.Config.GBaseStyles) == 0 && len(l.Manager.Config.GChecks) == 0 {
+	} else if len(file.Checks) == 0 && len(file.BaseStyles) == 0 && len(file.Tags) == 0 {
+		cfg := l.Manager.Config
+		if len(cfg.GBaseStyles) == 0 && len(cfg.GChecks) == 0 && len(cfg.GTags) == 0 {
 			// There's nothing to do; bail early.
 			return lintResult{file: file}
 		}
@@ -164,10 +196,18 @@ func (l *Linter) lintFile(src string) lintResult {
 		switch file.NormedExt {
 		case ".adoc":
 			err = l.lintADoc(file)
+		case ".ipynb":
+			err = l.lintNotebook(file)
 		case ".md":
 			err = l.lintMarkdown(file)
+		case ".mdx":
+			err = l.lintMDX(file)
+		case ".org":
+			err = l.lintOrg(file)
 		case ".rst":
 			err = l.lintRST(file)
+		case ".tex":
+			err = l.lintLaTeX(file)
 		case ".xml":
 			err = l.lintXML(file)
 		case ".dita":
@@ -177,6 +217,17 @@ func (l *Linter) lintFile(src string) lintResult {
 		}
 	} else if file.Format == "code" && !l.Manager.Config.Flags.Simple {
 		l.lintCode(file)
+	} else if file.Format == "commit" && !l.Manager.Config.Flags.Simple {
+		l.lintCommit(file)
+	} else if file.Format == "data" && !l.Manager.Config.Flags.Simple {
+		switch file.NormedExt {
+		case ".po":
+			err = l.lintPO(file)
+		case ".xliff":
+			err = l.lintXLIFF(file)
+		default:
+			err = l.lintData(file)
+		}
 	} else {
 		l.lintLines(file)
 	}
@@ -185,8 +236,17 @@ func (l *Linter) lintFile(src string) lintResult {
 }
 
 func (l *Linter) lintProse(f *core.File, parent core.Block, lines int) {
+	l.lintScopedProse(f, parent, "text", lines)
+}
+
+// lintScopedProse is lintProse for a part of a document that has its own
+// scope -- e.g., `text.body`, whose sentences and paragraphs are then scoped
+// as `sentence.body` and `paragraph.body`.
+func (l *Linter) lintScopedProse(f *core.File, parent core.Block, scope string, lines int) {
 	var b core.Block
 
+	nested := strings.TrimPrefix(scope, "text")
+
 	// FIXME: This is required for paragraphs that lack a newline delimiter:
 	//
 	// p1
@@ -198,24 +258,24 @@ func (l *Linter) lintProse(f *core.File, parent core.Block, lines int) {
 	text := core.Sanitize(parent.Text)
 	if l.Manager.HasScope("paragraph") || l.Manager.HasScope("sentence") {
 		for _, p := range strings.SplitAfter(text, "\n\n") {
-			for _, s := range core.SentenceTokenizer.Tokenize(p) {
+			for _, s := range core.SentenceTokenizerFor(f.Lang).Tokenize(p) {
 				b = core.NewLinedBlock(
 					parent.Context,
 					strings.TrimSpace(s),
-					"sentence"+f.RealExt,
+					"sentence"+nested+f.RealExt,
 					parent.Line)
 				l.lintBlock(f, b, lines, 0, needsLookup)
 			}
 			b = core.NewLinedBlock(
 				parent.Context,
 				p,
-				"paragraph"+f.RealExt,
+				"paragraph"+nested+f.RealExt,
 				parent.Line)
 			l.lintBlock(f, b, lines, 0, needsLookup)
 		}
 	}
 
-	b = core.NewLinedBlock(parent.Context, text, "text"+f.RealExt, parent.Line)
+	b = core.NewLinedBlock(parent.Context, text, scope+f.RealExt, parent.Line)
 	l.lintBlock(f, b, lines, 0, needsLookup)
 }
 
@@ -231,14 +291,72 @@ func (l *Linter) lintBlock(f *core.File, blk core.Block, lines, pad int, lookup
 			continue
 		}
 
+		alerts, err := chk.Run(l.context(), blk.Text, f)
+		if err != nil {
+			f.AddError(name, err)
+			continue
+		}
+
 		info := chk.Fields()
-		for _, a := range chk.Run(blk.Text, f) {
+		for _, a := range alerts {
+			a.Tags, a.Escalate = info.Tags, info.Escalate
 			core.FormatAlert(&a, info.Limit, info.Level, name)
 			f.AddAlert(a, blk, lines, pad, lookup)
 		}
 	}
 }
 
+// lintProject applies the results of any project-scoped rules, which need to
+// see every linted file before deciding what to report.
+//
+// For example, a project-scoped `consistency` rule reports every occurrence
+// of both of its options -- we then keep only those that belong to the less
+// common option.
+func (l *Linter) lintProject(linted []*core.File) {
+	type group struct{ check, name string }
+
+	counts := make(map[group]map[string]int)
+	for _, f := range linted {
+		for _, a := range f.Pending {
+			key := group{a.Check, a.Group}
+			if _, found := counts[key]; !found {
+				counts[key] = make(map[string]int)
+			}
+			counts[key][a.Variant]++
+		}
+	}
+
+	for _, f := range linted {
+		pending := f.Pending
+		f.Pending = nil
+		for _, a := range pending {
+			if a.Group == "" {
+				f.Pending = append(f.Pending, a)
+			} else if isMinority(counts[group{a.Check, a.Group}], a) {
+				a.Group, a.Variant = "", ""
+				f.AddLocatedAlert(a)
+			}
+		}
+	}
+}
+
+// isMinority determines if `a` matched the less common option of its group.
+//
+// In the case of a tie, we prefer the first option (i.e., the key in an
+// `either` map).
+func isMinority(variants map[string]int, a core.Alert) bool {
+	for variant, count := range variants {
+		if variant == a.Variant {
+			continue
+		} else if count > variants[a.Variant] {
+			return true
+		} else if count == variants[a.Variant] && variant == a.Group {
+			return true
+		}
+	}
+	return false
+}
+
 func (l *Linter) shouldRun(name string, f *core.File, chk check.Rule, blk core.Block) bool {
 	min := l.Manager.Config.MinAlertLevel
 	run := false
@@ -255,9 +373,11 @@ func (l *Linter) shouldRun(name string, f *core.File, chk check.Rule, blk core.B
 	// It has been disabled via an in-text comment.
 	if f.QueryComments(name) {
 		return false
-	} else if core.LevelToInt[details.Level] < min {
+	} else if maxLevel(details) < min {
+		return false
+	} else if !blk.Scope.Matches(details.Scope) {
 		return false
-	} else if !blk.Scope.ContainsString(details.Scope) {
+	} else if !core.MatchesLang(details.Lang, f.Lang) {
 		return false
 	}
 
@@ -277,6 +397,19 @@ func (l *Linter) shouldRun(name string, f *core.File, chk check.Rule, blk core.B
 		run = true
 	}
 
+	// Has the check been enabled or disabled by one of its tags?
+	if val, ok := tagSetting(details.Tags, f.Tags); ok && !run {
+		if !val {
+			return false
+		}
+		run = true
+	} else if val, ok := tagSetting(details.Tags, l.Manager.Config.GTags); ok && !run {
+		if !val {
+			return false
+		}
+		run = true
+	}
+
 	style := strings.Split(name, ".")[0]
 	if !run && !core.StringInSlice(style, f.BaseStyles) {
 		return false
@@ -285,6 +418,34 @@ func (l *Linter) shouldRun(name string, f *core.File, chk check.Rule, blk core.B
 	return true
 }
 
+// maxLevel is the highest level that a rule's alerts can have, taking into
+// account its `escalate` thresholds.
+func maxLevel(def check.Definition) int {
+	level := core.LevelToInt[def.Level]
+	for lvl := range def.Escalate {
+		if core.LevelToInt[lvl] > level {
+			level = core.LevelToInt[lvl]
+		}
+	}
+	return level
+}
+
+// tagSetting reports whether `settings` enables or disables a rule with the
+// given tags (its second value is `false` if none of them are mentioned). A
+// disabled tag takes precedence over an enabled one.
+func tagSetting(tags []string, settings map[string]bool) (bool, bool) {
+	enabled := false
+	for _, tag := range tags {
+		if on, found := settings[tag]; found {
+			if !on {
+				return false, true
+			}
+			enabled = true
+		}
+	}
+	return enabled, enabled
+}
+
 // setup handles any necessary building, compiling, or pre-processing.
 func (l *Linter) setup() error {
 	if l.Manager.Config.SphinxAuto != "" {
@@ -326,6 +487,8 @@ func (l *Linter) skip(fp string) bool {
 	if normed, found := l.Manager.Config.Formats[strings.Trim(old, ".")]; found {
 		ext = "." + normed
 		fp = fp[0:len(fp)-len(old)] + ext
+	} else if core.IsCommitMsg(fp) {
+		fp += ".commit"
 	}
 
 	fp = filepath.ToSlash(fp)
@@ -1,6 +1,7 @@
 package lint
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 
@@ -47,6 +48,27 @@ func TestGenderBias(t *testing.T) {
 	}
 }
 
+func TestLintCanceled(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{InExt: ".txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.GBaseStyles = []string{"Vale"}
+
+	mgr, err := check.NewManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	linter := (&Linter{Manager: mgr}).WithContext(ctx)
+	if _, err = linter.Lint([]string{"../../fixtures"}, "*.md"); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
 func benchmarkLint(path string, b *testing.B) {
 	cfg, err := core.NewConfig(&core.CLIFlags{})
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/jdkato/regexp"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
 	grh "github.com/yuin/goldmark/renderer/html"
 )
 
@@ -16,6 +17,9 @@ var goldMd = goldmark.New(
 	goldmark.WithExtensions(
 		extension.GFM,
 	),
+	goldmark.WithParserOptions(
+		parser.WithAutoHeadingID(),
+	),
 	goldmark.WithRendererOptions(
 		grh.WithUnsafe(),
 	),
@@ -26,15 +30,46 @@ var goldMd = goldmark.New(
 var reExInfo = regexp.MustCompile("`{3,}" + `.+`)
 
 func (l Linter) lintMarkdown(f *core.File) error {
-	var buf bytes.Buffer
+	ids, err := l.lintMarkdownBlocks(f)
+	if err != nil {
+		return err
+	}
+	l.lintDocument(f, ids)
+	return nil
+}
 
+// lintMarkdownBlocks lints each block of the Markdown file `f`, leaving its
+// document-level rules (see `lintDocument`) to the caller, and returns the
+// IDs of its elements.
+func (l Linter) lintMarkdownBlocks(f *core.File) (map[string]bool, error) {
 	s, err := l.prep(f.Content, "\n```\n$1\n```\n", "`$1`", ".md")
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := renderMarkdown(f, s)
+	if err != nil {
+		return nil, err
+	}
+	return l.lintHTMLBlocks(f, html, 0), nil
+}
+
+// lintMarkdownSource lints the (prepared) Markdown `s` as the content of `f`.
+func (l Linter) lintMarkdownSource(f *core.File, s string) error {
+	html, err := renderMarkdown(f, s)
 	if err != nil {
 		return err
 	}
+	return l.lintHTMLTokens(f, html, 0)
+}
+
+// renderMarkdown converts the (prepared) Markdown `s` to HTML and masks the
+// info strings of `f`'s code blocks.
+func renderMarkdown(f *core.File, s string) ([]byte, error) {
+	var buf bytes.Buffer
 
 	if err := goldMd.Convert([]byte(s), &buf); err != nil {
-		return core.NewE100(f.Path, err)
+		return nil, core.NewE100(f.Path, err)
 	}
 
 	// NOTE: This is required to avoid finding matches info strings. For
@@ -56,5 +91,5 @@ func (l Linter) lintMarkdown(f *core.File) error {
 	})
 
 	f.Content = body
-	return l.lintHTMLTokens(f, buf.Bytes(), 0)
+	return buf.Bytes(), nil
 }
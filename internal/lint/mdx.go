@@ -0,0 +1,31 @@
+package lint
+
+import (
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/mdx"
+)
+
+func (l Linter) lintMDX(f *core.File) error {
+	s, err := l.prep(f.Content, "\n```\n$1\n```\n", "`$1`", ".mdx")
+	if err != nil {
+		return err
+	}
+
+	// NOTE: We convert the original content too (rather than just the
+	// prepared content) because `prep` can change its line numbers.
+	src := mdx.Convert(f.Content, f.JSXProps)
+	f.Content = src.Masked
+
+	if err = l.lintMarkdownSource(f, mdx.Convert(s, nil).Markdown); err != nil {
+		return err
+	}
+
+	// Props are linted like `alt` text in HTML -- i.e., using the
+	// `text.attr.<name>` scope.
+	for _, p := range src.Props {
+		blk := core.NewLinedBlock(f.Content, p.Value, "text.attr."+p.Name, p.Line-1)
+		l.lintBlock(f, blk, len(f.Lines), 0, false)
+	}
+
+	return nil
+}
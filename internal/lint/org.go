@@ -0,0 +1,18 @@
+package lint
+
+import (
+	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/org"
+)
+
+func (l Linter) lintOrg(f *core.File) error {
+	s, err := l.prep(
+		f.Content, "\n#+BEGIN_EXAMPLE\n$1\n#+END_EXAMPLE\n", "=$1=", ".org")
+	if err != nil {
+		return err
+	}
+
+	// NOTE: See `lintMDX` for why we convert the original content too.
+	f.Content = org.Convert(f.Content).Masked
+	return l.lintHTMLTokens(f, []byte(org.Convert(s).HTML), 0)
+}
@@ -0,0 +1,75 @@
+package lint
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/errata-ai/vale/v2/internal/core"
+	"golang.org/x/net/html"
+)
+
+// tagToBlock maps top-level HTML tags to the block types recorded in a
+// document's outline.
+var tagToBlock = map[string]string{
+	"p":          "paragraph",
+	"ul":         "list",
+	"ol":         "list",
+	"dl":         "list",
+	"table":      "table",
+	"pre":        "code",
+	"blockquote": "blockquote",
+	"img":        "image",
+	"hr":         "rule",
+}
+
+// outliner builds a `core.File`'s outline from the HTML token stream seen
+// by `lintHTMLTokens`.
+type outliner struct {
+	// depth tracks how deeply we're nested within block-level elements, which
+	// allows us to only record top-level blocks (e.g., a list rather than its
+	// paragraphs).
+	depth int
+
+	heading bool
+	text    strings.Builder
+}
+
+func (o *outliner) visit(f *core.File, tokt html.TokenType, tok html.Token, w *walker) {
+	switch tokt {
+	case html.StartTagToken, html.SelfClosingTagToken:
+		if heading.MatchString(tok.Data) && o.depth == 0 {
+			level, _ := strconv.Atoi(tok.Data[1:])
+			f.Outline = append(f.Outline, core.Section{Level: level})
+			o.heading = true
+			o.text.Reset()
+		} else if block, ok := tagToBlock[tok.Data]; ok {
+			if o.depth == 0 {
+				o.add(f, block, w)
+			}
+			if tokt == html.StartTagToken && tok.Data != "img" && tok.Data != "hr" {
+				o.depth++
+			}
+		}
+	case html.EndTagToken:
+		if heading.MatchString(tok.Data) && o.heading {
+			sec := &f.Outline[len(f.Outline)-1]
+			sec.Text = strings.TrimSpace(o.text.String())
+			sec.Line = w.firstLine()
+			o.heading = false
+		} else if _, ok := tagToBlock[tok.Data]; ok && o.depth > 0 {
+			o.depth--
+		}
+	case html.TextToken:
+		if o.heading {
+			o.text.WriteString(tok.Data)
+		}
+	}
+}
+
+func (o *outliner) add(f *core.File, block string, w *walker) {
+	if len(f.Outline) == 0 {
+		f.Outline = append(f.Outline, core.Section{Line: w.firstLine()})
+	}
+	last := &f.Outline[len(f.Outline)-1]
+	last.Blocks = append(last.Blocks, block)
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/rst"
 	"github.com/jdkato/regexp"
 )
 
@@ -30,6 +31,9 @@ var reCodeBlock = regexp.MustCompile(`.. (?:raw|code(?:-block)?):: (\w+)`)
 //
 // See https://github.com/errata-ai/vale/v2/issues/119.
 var reSphinx = regexp.MustCompile(`.. glossary::`)
+
+// reDataLine matches the source lines recorded by our own reST parser.
+var reDataLine = regexp.MustCompile(` data-line="\d+"`)
 var rstArgs = []string{
 	"--quiet",
 	"--halt=5",
@@ -147,9 +151,7 @@ func (l *Linter) lintRST(f *core.File) error {
 	python := core.Which([]string{
 		"python", "py", "python.exe", "python3", "python3.exe", "py3"})
 
-	if rst2html == "" || python == "" {
-		return core.NewE100("lintRST", errors.New("rst2html not found"))
-	} else if l.Manager.Config.SphinxBuild != "" {
+	if l.Manager.Config.SphinxBuild != "" {
 		return l.lintSphinx(f)
 	}
 
@@ -158,6 +160,17 @@ func (l *Linter) lintRST(f *core.File) error {
 		return err
 	}
 
+	if rst2html == "" || python == "" {
+		// docutils isn't available, so we use our own (pure-Go) parser.
+		html = rst.ToHTML(s)
+		if strings.Count(s, "\n") != strings.Count(f.Content, "\n") {
+			// NOTE: Our preprocessing (e.g., of front matter) added lines,
+			// so the parser's source lines don't match the file's.
+			html = reDataLine.ReplaceAllString(html, "")
+		}
+		return l.lintHTMLTokens(f, []byte(html), 0)
+	}
+
 	s = reSphinx.ReplaceAllString(s, ".. code::")
 	s = reCodeBlock.ReplaceAllString(s, "::")
 
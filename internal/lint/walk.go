@@ -19,6 +19,15 @@ type walker struct {
 	idx int
 	z   *html.Tokenizer
 
+	// masked is the length of the prefix of `context` that `seek` has
+	// masked, which ends at the start of the line `maskedLine`.
+	masked     int
+	maskedLine int
+
+	// anchored is true if the current block's line came from `seek`, in
+	// which case we only move past it for an exact match of a line of text.
+	anchored bool
+
 	// queue holds each segment of text we encounter in a block, which we then
 	// use to sequentially update our context.
 	queue []string
@@ -42,6 +51,7 @@ func (w *walker) reset() {
 	}
 	w.queue = []string{}
 	w.tagHistory = []string{}
+	w.anchored = false
 }
 
 func (w *walker) append(text string) {
@@ -70,6 +80,55 @@ func (w *walker) block(text, scope string) core.Block {
 	return core.NewLinedBlock(w.context, text, scope, line)
 }
 
+// seek moves the walker to the (0-based) source line `line` -- e.g., from a
+// block's `data-line` attribute -- by masking the context that precedes it,
+// which ensures that the block's text isn't matched to an earlier line.
+func (w *walker) seek(line int) {
+	w.anchored = true
+	if line <= w.maskedLine {
+		return
+	}
+
+	end := w.masked
+	for n := w.maskedLine; n < line; n++ {
+		i := strings.IndexByte(w.context[end:], '\n')
+		if i < 0 {
+			return
+		}
+		end += i + 1
+	}
+
+	prefix := strings.Map(func(r rune) rune {
+		if r != '\n' {
+			return '@'
+		}
+		return r
+	}, w.context[w.masked:end])
+
+	w.context = w.context[:w.masked] + prefix + w.context[end:]
+	w.masked += len(prefix)
+	w.maskedLine = line
+	if line > w.idx {
+		w.idx = line
+	}
+}
+
+// firstLine returns the (1-based) source line of the current block's first
+// line of text.
+func (w *walker) firstLine() int {
+	for _, text := range w.queue {
+		for _, s := range strings.Split(text, "\n") {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			} else if pos := strings.Index(w.context, s); pos >= 0 {
+				return strings.Count(w.context[:pos], "\n") + 1
+			}
+			return w.idx + 1
+		}
+	}
+	return w.idx + 1
+}
+
 func (w *walker) walk() (html.TokenType, html.Token, string) {
 	tokt := w.z.Next()
 	tok := w.z.Token()
@@ -93,7 +152,7 @@ func (w *walker) advance(text string) int {
 	pos := 0
 	for _, s := range strings.Split(text, "\n") {
 		pos = strings.Index(w.context, s)
-		if pos < 0 {
+		if pos < 0 && !w.anchored {
 			for _, ss := range strings.Fields(s) {
 				pos = strings.Index(w.context, ss)
 			}
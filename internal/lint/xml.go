@@ -2,11 +2,11 @@ package lint
 
 import (
 	"bytes"
-	"errors"
 	"os/exec"
 	"strings"
 
 	"github.com/errata-ai/vale/v2/internal/core"
+	"github.com/errata-ai/vale/v2/pkg/xmlscope"
 )
 
 // XML configuration.
@@ -23,12 +23,8 @@ func (l Linter) lintXML(file *core.File) error {
 	var out bytes.Buffer
 
 	xsltproc := core.Which([]string{"xsltproc", "xsltproc.exe"})
-	if xsltproc == "" {
-		return core.NewE100("lintXML", errors.New("xsltproc not found"))
-	} else if file.Transform == "" {
-		return core.NewE100(
-			"lintXML",
-			errors.New("no XSLT transform provided"))
+	if len(file.XMLScopes) > 0 || file.Transform == "" || xsltproc == "" {
+		return l.lintXMLScopes(file, "docbook")
 	}
 
 	xsltArgs = append(xsltArgs, []string{file.Transform, "-"}...)
@@ -43,3 +39,32 @@ func (l Linter) lintXML(file *core.File) error {
 
 	return l.lintHTMLTokens(file, out.Bytes(), 0)
 }
+
+// lintXMLScopes lints an XML document using our own (pure-Go) converter,
+// which maps elements to scopes according to the file's `XMLScopes` (using
+// `preset` unless another preset is given).
+func (l Linter) lintXMLScopes(file *core.File, preset string) error {
+	entries := file.XMLScopes
+
+	named := false
+	for _, entry := range entries {
+		if _, found := xmlscope.Presets[strings.ToLower(strings.TrimSpace(entry))]; found {
+			named = true
+		}
+	}
+	if !named {
+		entries = append([]string{preset}, entries...)
+	}
+
+	mapper, err := xmlscope.New(entries)
+	if err != nil {
+		return core.NewE100(file.Path, err)
+	}
+
+	out, err := mapper.ToHTML([]byte(file.Content))
+	if err != nil {
+		return core.NewE100(file.Path, err)
+	}
+
+	return l.lintHTMLTokens(file, []byte(out), 0)
+}
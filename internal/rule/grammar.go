@@ -2,6 +2,7 @@ package rule
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -13,6 +14,9 @@ import (
 	"github.com/errata-ai/vale/v2/internal/core"
 )
 
+// NOTE: `skipped`, `disabled` and `enabled` are our defaults for the
+// `disabledRules`, `disabledCategories` and `enabledCategories` parameters,
+// respectively (see `core.LTOptions`).
 var skipped = []string{
 	// Collides with `Vale.Repetition`
 	"ENGLISH_WORD_REPEAT_RULE",
@@ -58,31 +62,103 @@ type LTResult struct {
 
 // CheckWithLT interfaces with a running instace of LanguageTool.
 //
-// TODO: How do we speed this up?
-func CheckWithLT(text string, f *core.File, cfg *core.Config) ([]core.Alert, error) {
+// The entire text is sent in a single request, which is retried (with a
+// short backoff) up to `opts.Retries` times if the server can't be reached
+// or reports a server-side error.
+func CheckWithLT(ctx context.Context, text string, f *core.File, cfg *core.Config, opts core.LTOptions) ([]core.Alert, error) {
 	alerts := []core.Alert{}
 
-	resp, err := checkWithURL(text, "en-US", cfg.LTPath, cfg.Timeout)
+	var resp LTResult
+	var err error
+
+	form := makeForm(text, f, opts)
+	for attempt := 0; attempt <= opts.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return alerts, ctx.Err()
+			case <-time.After(time.Duration(attempt) * retryDelay):
+			}
+		}
+		resp, err = checkWithURL(ctx, form, cfg.LTPath, cfg.Timeout)
+		if _, ok := err.(permanentError); err == nil || ok || ctx.Err() != nil {
+			break
+		}
+	}
+
 	if err != nil {
 		return alerts, err
 	}
 
+	// NOTE: Since we're checking the document's prose (rather than its
+	// source), LanguageTool's offsets are mapped back to the source using
+	// the prose's segments; if that isn't possible, the alert is located by
+	// searching for its match (see `File.AddAlert`).
+	located := text == f.Prose.String()
+
+	runes := []rune(text)
 	for _, m := range resp.Matches {
-		alerts = append(alerts, matchToAlert(m))
+		a := matchToAlert(m, runes)
+		if located {
+			if line, span, ok := f.ProseLoc(a.Span[0], a.Match); ok {
+				a.Line, a.Span = line, span
+			}
+		}
+		alerts = append(alerts, a)
 	}
 
 	return alerts, nil
 }
 
+// makeForm creates the parameters of a `/v2/check` request.
+func makeForm(text string, f *core.File, opts core.LTOptions) url.Values {
+	data := url.Values{}
+
+	lang := opts.Language
+	if lang == "" {
+		lang = f.Lang
+	}
+	if lang == "" {
+		lang = core.DefaultLang
+	}
+
+	data.Set("text", text)
+	data.Set("language", lang)
+	data.Set("enabledCategories", strings.Join(orDefault(opts.EnabledCategories, enabled), ","))
+	data.Set("disabledCategories", strings.Join(orDefault(opts.DisabledCategories, disabled), ","))
+	data.Set("disabledRules", strings.Join(orDefault(opts.DisabledRules, skipped), ","))
+	if len(opts.EnabledRules) > 0 {
+		data.Set("enabledRules", strings.Join(opts.EnabledRules, ","))
+	}
+	if opts.MotherTongue != "" {
+		data.Set("motherTongue", opts.MotherTongue)
+	}
+
+	return data
+}
+
+func orDefault(values, defaults []string) []string {
+	if values == nil {
+		return defaults
+	}
+	return values
+}
+
 // Convert a LanguageTool-style Match object to an Alert.
-func matchToAlert(m match) core.Alert {
-	ctx := m.Context
+func matchToAlert(m match, text []rune) core.Alert {
+	start, end := m.Offset, m.Offset+m.Length
 
-	start, end := ctx.Offset, ctx.Offset+ctx.Length
-	// NOTE: this is necessary.
-	//
-	// See https://godoc.org/golang.org/x/exp/utf8string ??
-	target := string([]rune(ctx.Text)[start:end])
+	var target string
+	if start >= 0 && end <= len(text) && start < end {
+		target = string(text[start:end])
+	} else {
+		ctx := m.Context
+		start, end = ctx.Offset, ctx.Offset+ctx.Length
+		// NOTE: this is necessary.
+		//
+		// See https://godoc.org/golang.org/x/exp/utf8string ??
+		target = string([]rune(ctx.Text)[start:end])
+	}
 
 	suggestions := replacementsToParams(m.Replacements)
 
@@ -124,19 +200,21 @@ func replacementsToParams(options []replacement) []string {
 	return suggestions
 }
 
-func checkWithURL(text, lang, apiURL string, timeout int) (LTResult, error) {
-	data := url.Values{}
+// retryDelay is how long we wait (multiplied by the attempt number) before
+// retrying a failed request.
+var retryDelay = 250 * time.Millisecond
 
-	data.Set("text", text)
-	data.Set("language", lang)
-	data.Set("enabledCategories", strings.Join(enabled, ","))
-	data.Set("disabledCategories", strings.Join(disabled, ","))
-	data.Set("disabledRules", strings.Join(skipped, ","))
-	data.Set("motherTongue", "en")
+// A permanentError is a request failure that retrying won't fix -- e.g., an
+// unsupported language.
+type permanentError struct {
+	error
+}
 
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBufferString(data.Encode()))
+func checkWithURL(ctx context.Context, data url.Values, apiURL string, timeout int) (LTResult, error) {
+	req, err := http.NewRequestWithContext(
+		ctx, "POST", apiURL, bytes.NewBufferString(data.Encode()))
 	if err != nil {
-		return LTResult{}, err
+		return LTResult{}, permanentError{err}
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Accept", "application/json")
@@ -150,19 +228,26 @@ func checkWithURL(text, lang, apiURL string, timeout int) (LTResult, error) {
 	}
 	defer resp.Body.Close()
 
-	body, _ := ioutil.ReadAll(resp.Body)
-	result := LTResult{}
-	err = json.Unmarshal(body, &result)
+	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return LTResult{}, err
 	}
-	if resp.StatusCode == 200 {
-		return result, nil
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("LanguageTool returned %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(body)))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return LTResult{}, err
+		}
+		return LTResult{}, permanentError{err}
 	}
 
-	// TODO handle other status codes
+	result := LTResult{}
+	if err = json.Unmarshal(body, &result); err != nil {
+		return LTResult{}, permanentError{err}
+	}
 
-	return LTResult{}, nil
+	return result, nil
 }
 
 type warnings struct {
@@ -188,7 +273,7 @@ type match struct {
 	Replacements []replacement `json:"replacements"`
 	Offset       int           `json:"offset"`
 	Length       int           `json:"length"`
-	Context      context       `json:"context"`
+	Context      matchContext  `json:"context"`
 	Rule         rule          `json:"rule"`
 }
 
@@ -196,7 +281,7 @@ type replacement struct {
 	Value string `json:"value"`
 }
 
-type context struct {
+type matchContext struct {
 	Text   string `json:"text"`
 	Offset int    `json:"offset"`
 	Length int    `json:"length"`
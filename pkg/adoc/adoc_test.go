@@ -0,0 +1,94 @@
+package adoc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var htmlTests = []struct {
+	adoc     string
+	contains []string
+	excludes []string
+}{
+	{"= Title\nAuthor Name\n:product: Vale\n\n== Section\n\nUse {product}{missing}.", []string{
+		"<h1>Title</h1>", "<h2>Section</h2>", "<p>Use Vale.</p>"}, []string{"Author"}},
+	{"* One\n** Two\n* Three\n+\nMore.\n\n//\n. Four", []string{
+		"<ul>\n<li><p>One</p>\n<ul>\n<li><p>Two</p>\n</li>\n</ul>\n</li>",
+		"<li><p>Three</p>\n<p>More.</p>\n</li>", "<ol>\n<li><p>Four</p>"}, nil},
+	{"CPU:: The brain.\nRAM::\n  The memory.", []string{
+		"<dt>CPU</dt>\n<dd><p>The brain.</p>", "<dt>RAM</dt>\n<dd><p>The memory.</p>"}, nil},
+	{"|===\n|A |B\n\n|1\n|2\n\n2+|3\n|===", []string{
+		"<tr><th><p>A</p>\n</th><th><p>B</p>\n</th></tr>",
+		"<tr><td><p>1</p>\n</td><td><p>2</p>\n</td></tr>", "<td><p>3</p>"}, nil},
+	{"[cols=\"2*\"]\n|===\n|A\na|* B\n|===", []string{
+		"<td><p>A</p>\n</td><td><ul>\n<li><p>B</p>"}, nil},
+	{"NOTE: Be careful.\n\n[WARNING]\n====\nReally.\n====", []string{
+		"<div class=\"admonitionblock note\">\n<p>Be careful.</p>",
+		"<div class=\"admonitionblock warning\">\n<p>Really.</p>"}, nil},
+	{"[source,go]\n----\nx := \"TODO\"\n----\n\n....\nliteral\n....\n\n  indented", []string{
+		"<pre>x := &#34;TODO&#34;</pre>", "<pre>literal</pre>", "<pre>indented</pre>"}, nil},
+	{"Use `code`, *bold*, _em_, and https://vale.sh[Vale]. See <<intro,the intro>>.", []string{
+		"<code>code</code>", "<strong>bold</strong>", "<em>em</em>",
+		`<a href="https://vale.sh">Vale</a>`, `<a href="#intro">the intro</a>`}, nil},
+	{"2*3*4 and snake_case_name are not markup.", []string{
+		"<p>2*3*4 and snake_case_name are not markup.</p>"}, nil},
+	{"pass:[<!-- vale off -->]\n\n////\nhidden\n////\n\n// hidden", []string{
+		"<!-- vale off -->"}, []string{"hidden"}},
+	{"ifdef::missing[]\nhidden\nendif::[]\nifndef::missing[]\nshown\nendif::[]", []string{
+		"shown"}, []string{"hidden"}},
+}
+
+func TestToHTML(t *testing.T) {
+	for _, tt := range htmlTests {
+		out, _ := ToHTML(tt.adoc, "")
+		for _, s := range tt.contains {
+			if !strings.Contains(out, s) {
+				t.Errorf("%q: expected %q in:\n%s", tt.adoc, s, out)
+			}
+		}
+		for _, s := range tt.excludes {
+			if strings.Contains(out, s) {
+				t.Errorf("%q: unexpected %q in:\n%s", tt.adoc, s, out)
+			}
+		}
+	}
+}
+
+func TestInclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "adoc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for name, src := range map[string]string{
+		"attrs.adoc": ":product: Vale\n\nThis is {product}.\n\ninclude::loop.adoc[]\n",
+		"loop.adoc":  "Back again.\n\ninclude::attrs.adoc[]\n",
+	} {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, includes := ToHTML("include::attrs.adoc[]\n\nUse {product}.", dir)
+	if !strings.Contains(out, "<p>Use Vale.</p>") {
+		t.Errorf("expected the included attribute in:\n%s", out)
+	} else if strings.Contains(out, "This is") {
+		t.Errorf("unexpected included content in:\n%s", out)
+	}
+
+	if len(includes) != 1 || includes[0].Target != "attrs.adoc" {
+		t.Fatalf("expected a single include, got %v", includes)
+	} else if !strings.Contains(includes[0].HTML, "<p>This is Vale.</p>") {
+		t.Errorf("expected the included content in:\n%s", includes[0].HTML)
+	}
+
+	// `loop.adoc` includes `attrs.adoc`, which is already being included.
+	nested := includes[0].Includes
+	if len(nested) != 1 || len(nested[0].Includes) != 0 {
+		t.Errorf("expected the include cycle to be broken, got %v", nested)
+	}
+}
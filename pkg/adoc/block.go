@@ -0,0 +1,548 @@
+package adoc
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxDepth is the maximum include depth (the same as Asciidoctor's default).
+const maxDepth = 64
+
+var (
+	reAttrEntry   = regexp.MustCompile(`^:(!?\w[\w-]*!?):(?:[ \t]+(.*))?$`)
+	reAnchor      = regexp.MustCompile(`^\[\[\[?[\w:.-]*(?:,[^\]]*)?\]\]\]?$`)
+	reBlockAttrs  = regexp.MustCompile(`^\[(?:|[\w.#%{,"'].*)\]$`)
+	reBlockTitle  = regexp.MustCompile(`^\.[^.\s].*$`)
+	reSection     = regexp.MustCompile(`^(={1,6}|#{1,6})[ \t]+(\S.*?)(?:[ \t]+=+)?$`)
+	reDelimiter   = regexp.MustCompile("^(?:/{4,}|-{4,}|\\.{4,}|_{4,}|={4,}|\\*{4,}|\\+{4,}|--|```\\w*)$")
+	reTable       = regexp.MustCompile(`^[|,:!]={3,}$`)
+	reInclude     = regexp.MustCompile(`^include::([^\[]+)\[(.*)\]$`)
+	reConditional = regexp.MustCompile(`^(ifn?def|ifeval)::([^\[]*)\[(.*)\]$`)
+	reEndif       = regexp.MustCompile(`^endif::[^\[]*\[\]$`)
+	reImageBlock  = regexp.MustCompile(`^image::([^\s\[]+)\[(.*)\]$`)
+	reBlockMacro  = regexp.MustCompile(`^\w[\w-]*::\S*\[.*\]$`)
+	reAdmonition  = regexp.MustCompile(`^(NOTE|TIP|IMPORTANT|WARNING|CAUTION):[ \t]+(.*)$`)
+	reBreak       = regexp.MustCompile(`^(?:'{3,}|<{3,}|(?:[-*_] ?){3})$`)
+	reURI         = regexp.MustCompile(`^\w{2,}://`)
+)
+
+// admonitions are the styles that mark an admonition block or paragraph.
+var admonitions = []string{"NOTE", "TIP", "IMPORTANT", "WARNING", "CAUTION"}
+
+// parser converts AsciiDoc blocks into HTML.
+type parser struct {
+	out bytes.Buffer
+
+	attrs    map[string]string
+	dir      string    // the directory that include paths are relative to
+	chain    []string  // the paths of the files being included, outermost first
+	includes []Include // the files included by the document
+
+	footnotes []string // footnotes yet to be written
+	inList    bool     // whether we're in a list item (see `paragraph`)
+}
+
+// blockMeta holds the attributes and title that precede a block.
+type blockMeta struct {
+	style   string
+	title   string
+	named   map[string]string
+	options []string
+}
+
+func (m blockMeta) hasOption(name string) bool {
+	for _, opt := range m.options {
+		if opt == name {
+			return true
+		}
+	}
+	return false
+}
+
+// An Include is a file included by a document's `include::` directive.
+type Include struct {
+	Target   string    // the directive's target, as written
+	Path     string    // the included file's path
+	Source   string    // the included file's content
+	HTML     string    // the included file's content, converted to HTML
+	Includes []Include // the files included by the included file
+}
+
+// ToHTML converts the AsciiDoc `src` into HTML. Any `include::` directives
+// are resolved relative to `dir` (or ignored, if `dir` is empty).
+//
+// Since an included file's content has no position in `src`, it's converted
+// separately (using the attributes in effect at its directive) and returned
+// alongside the HTML of `src`.
+func ToHTML(src, dir string) (string, []Include) {
+	p := parser{attrs: make(map[string]string), dir: dir}
+
+	lines := splitLines(src)
+	p.body(lines[p.header(lines):])
+
+	return p.out.String(), p.includes
+}
+
+// header converts the document header (its title, author and revision lines,
+// and attribute entries), returning the index of the first line after it.
+func (p *parser) header(lines []string) int {
+	i := 0
+	for i < len(lines) && (isBlank(lines[i]) || isComment(lines[i])) {
+		i++
+	}
+
+	if i == len(lines) || !strings.HasPrefix(lines[i], "= ") {
+		return 0
+	}
+	p.out.WriteString("<h1>" + p.inline(strings.TrimSpace(lines[i][2:])) + "</h1>\n")
+
+	for i++; i < len(lines) && !isBlank(lines[i]); i++ {
+		if m := reAttrEntry.FindStringSubmatch(lines[i]); m != nil {
+			i = p.setAttr(lines, i, m)
+		}
+		// Otherwise, it's a comment or an author or revision line.
+	}
+
+	return i
+}
+
+// body converts a sequence of blocks.
+func (p *parser) body(lines []string) {
+	for i := 0; i < len(lines); {
+		if isBlank(lines[i]) {
+			i++
+			continue
+		}
+		i = p.block(lines, i)
+		p.flushFootnotes()
+	}
+}
+
+// block converts the block starting at `lines[i]` (including its attribute
+// lines and title), returning the index of the first line after it.
+func (p *parser) block(lines []string, i int) int {
+	meta := blockMeta{named: make(map[string]string)}
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isBlank(line) || isComment(line) || reAnchor.MatchString(line) {
+			continue
+		} else if reBlockAttrs.MatchString(line) {
+			meta.parse(p.substitute(line[1 : len(line)-1]))
+		} else if reBlockTitle.MatchString(line) {
+			meta.title = line[1:]
+		} else {
+			break
+		}
+	}
+
+	if i == len(lines) {
+		return i
+	}
+	return p.element(lines, i, meta)
+}
+
+func (p *parser) element(lines []string, i int, meta blockMeta) int {
+	line := lines[i]
+
+	switch {
+	case reAttrEntry.MatchString(line):
+		return p.setAttr(lines, i, reAttrEntry.FindStringSubmatch(line)) + 1
+	case reInclude.MatchString(line):
+		p.include(reInclude.FindStringSubmatch(line)[1])
+		return i + 1
+	case reConditional.MatchString(line):
+		return p.conditional(lines, i, reConditional.FindStringSubmatch(line))
+	case reEndif.MatchString(line):
+		return i + 1
+	case reTable.MatchString(line):
+		return p.table(lines, i, meta)
+	case reDelimiter.MatchString(line):
+		return p.delimited(lines, i, meta)
+	case reSection.MatchString(line):
+		m := reSection.FindStringSubmatch(line)
+		level := len(m[1])
+		p.out.WriteString(fmt.Sprintf(
+			"<h%d>%s</h%d>\n", level, p.inline(m[2]), level))
+		return i + 1
+	case reImageBlock.MatchString(line):
+		p.writeTitle(meta)
+		p.out.WriteString("<div class=\"imageblock\">" +
+			p.image(reImageBlock.FindStringSubmatch(line)[1:]) + "</div>\n")
+		return i + 1
+	case reBlockMacro.MatchString(line):
+		// E.g., `toc::[]` or `video::intro.mp4[]`.
+		return i + 1
+	case reBreak.MatchString(line):
+		p.out.WriteString("<hr/>\n")
+		return i + 1
+	case listItem(line) != nil:
+		return p.list(lines, i, nil)
+	case indentOf(line) > 0 && meta.style == "":
+		// A literal paragraph.
+		end := i
+		for end < len(lines) && !isBlank(lines[end]) {
+			end++
+		}
+		p.writeTitle(meta)
+		p.literal(dedent(lines[i:end]))
+		return end
+	}
+
+	return p.paragraph(lines, i, meta)
+}
+
+// paragraph converts a paragraph, which ends at a blank line or the start of
+// another block (or, in a list, another list item).
+func (p *parser) paragraph(lines []string, i int, meta blockMeta) int {
+	end := i + 1
+	for end < len(lines) && !isBlank(lines[end]) {
+		line := lines[end]
+		if reDelimiter.MatchString(line) || reTable.MatchString(line) ||
+			reBlockAttrs.MatchString(line) || (p.inList && (line == "+" || listItem(line) != nil)) {
+			break
+		}
+		end++
+	}
+	text := lines[i:end]
+
+	p.writeTitle(meta)
+
+	style := meta.style
+	if m := reAdmonition.FindStringSubmatch(text[0]); m != nil && style == "" {
+		style, text = m[1], append([]string{m[2]}, text[1:]...)
+	}
+
+	switch {
+	case style == "comment":
+	case style == "pass":
+		p.out.WriteString(strings.Join(text, "\n") + "\n")
+	case isLiteralStyle(style) || style == "verse":
+		p.literal(text)
+	case style == "quote":
+		p.out.WriteString("<blockquote>\n" + p.para(text) + "</blockquote>\n")
+	case isAdmonition(style):
+		p.out.WriteString(admonition(style) + p.para(text) + "</div>\n")
+	default:
+		p.out.WriteString(p.para(text))
+	}
+
+	return end
+}
+
+// delimited converts a delimited block (e.g., `----` or `====`).
+func (p *parser) delimited(lines []string, i int, meta blockMeta) int {
+	delim := lines[i]
+	if strings.HasPrefix(delim, "```") {
+		delim = "```"
+	}
+
+	end := i + 1
+	for end < len(lines) && lines[end] != delim {
+		end++
+	}
+	content := lines[i+1 : end]
+
+	style := meta.style
+	if style == "" || delim[0] == '/' {
+		style = map[byte]string{
+			'/': "comment",
+			'-': "listing",
+			'`': "listing",
+			'.': "literal",
+			'_': "quote",
+			'=': "example",
+			'*': "sidebar",
+			'+': "pass",
+		}[delim[0]]
+		if delim == "--" {
+			style = "open"
+		}
+	}
+
+	if style != "comment" {
+		p.writeTitle(meta)
+	}
+
+	switch {
+	case style == "comment":
+	case style == "pass":
+		p.out.WriteString(strings.Join(content, "\n") + "\n")
+	case isLiteralStyle(style) || style == "verse" || isMath(style):
+		p.literal(content)
+	case style == "quote":
+		p.out.WriteString("<blockquote>\n")
+		p.body(content)
+		p.out.WriteString("</blockquote>\n")
+	case isAdmonition(style):
+		p.out.WriteString(admonition(style))
+		p.body(content)
+		p.out.WriteString("</div>\n")
+	default:
+		p.out.WriteString("<div class=\"" + style + "block\">\n")
+		p.body(content)
+		p.out.WriteString("</div>\n")
+	}
+
+	return end + 1
+}
+
+func (p *parser) literal(lines []string) {
+	p.out.WriteString("<pre>")
+	p.out.WriteString(html.EscapeString(strings.Join(lines, "\n")))
+	p.out.WriteString("</pre>\n")
+}
+
+// para converts the lines of a paragraph.
+func (p *parser) para(lines []string) string {
+	text := make([]string, len(lines))
+	for i, line := range lines {
+		text[i] = strings.TrimSpace(line)
+	}
+	return "<p>" + p.inline(strings.Join(text, "\n")) + "</p>\n"
+}
+
+func (p *parser) writeTitle(meta blockMeta) {
+	if meta.title != "" {
+		p.out.WriteString("<div class=\"title\">" + p.inline(meta.title) + "</div>\n")
+	}
+}
+
+func (p *parser) flushFootnotes() {
+	for _, note := range p.footnotes {
+		p.out.WriteString("<div class=\"footnote\">" + p.spans(note) + "</div>\n")
+	}
+	p.footnotes = nil
+}
+
+// setAttr handles the attribute entry `m` found at `lines[i]`, returning the
+// index of its last line.
+func (p *parser) setAttr(lines []string, i int, m []string) int {
+	name, value := m[1], m[2]
+	if strings.HasPrefix(name, "!") || strings.HasSuffix(name, "!") {
+		delete(p.attrs, strings.Trim(name, "!"))
+		return i
+	}
+
+	// A value may be continued onto the next line(s) with a trailing " \".
+	for strings.HasSuffix(value, " \\") && i+1 < len(lines) {
+		i++
+		value = strings.TrimSuffix(value, "\\") + strings.TrimSpace(lines[i])
+	}
+
+	p.attrs[name] = p.substitute(value)
+	return i
+}
+
+// include converts the file at `target` and adds it to the document's
+// includes (see `ToHTML`).
+func (p *parser) include(target string) {
+	path := p.substitute(target)
+	if p.dir == "" || len(p.chain) >= maxDepth || reURI.MatchString(path) {
+		return
+	} else if !filepath.IsAbs(path) {
+		path = filepath.Join(p.dir, path)
+	}
+
+	for _, outer := range p.chain {
+		if outer == path {
+			// The file (directly or indirectly) includes itself.
+			return
+		}
+	}
+
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	chain := append(append([]string{}, p.chain...), path)
+	sub := parser{attrs: p.attrs, dir: filepath.Dir(path), chain: chain}
+	sub.body(splitLines(string(src)))
+
+	p.includes = append(p.includes, Include{
+		Target: target, Path: path, Source: string(src),
+		HTML: sub.out.String(), Includes: sub.includes})
+}
+
+// conditional handles an `ifdef`, `ifndef`, or `ifeval` directive, returning
+// the index of the first line after it (or after its `endif`, if its
+// content is excluded).
+func (p *parser) conditional(lines []string, i int, m []string) int {
+	kind, names, text := m[1], m[2], m[3]
+
+	// NOTE: We don't evaluate `ifeval` expressions.
+	include := true
+	if kind != "ifeval" {
+		include = p.isDefined(names) == (kind == "ifdef")
+	}
+
+	if text != "" {
+		// A single-line conditional.
+		if include {
+			p.body([]string{text})
+		}
+		return i + 1
+	} else if include {
+		return i + 1
+	}
+
+	depth := 0
+	for i++; i < len(lines); i++ {
+		if reEndif.MatchString(lines[i]) {
+			if depth == 0 {
+				return i + 1
+			}
+			depth--
+		} else if m := reConditional.FindStringSubmatch(lines[i]); m != nil && m[3] == "" {
+			depth++
+		}
+	}
+
+	return i
+}
+
+// isDefined reports whether the attributes in `names`, which are separated
+// by "," (any) or "+" (all), are defined.
+func (p *parser) isDefined(names string) bool {
+	if strings.Contains(names, "+") {
+		for _, name := range strings.Split(names, "+") {
+			if _, found := p.attrs[name]; !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, name := range strings.Split(names, ",") {
+		if _, found := p.attrs[name]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// parse reads a block attribute list, such as `[source,go]` or
+// `[NOTE#id.role%collapsible]`.
+func (m *blockMeta) parse(list string) {
+	for i, attr := range splitAttrs(list) {
+		if parts := strings.SplitN(attr, "=", 2); len(parts) == 2 {
+			key, value := strings.TrimSpace(parts[0]), unquote(parts[1])
+			m.named[key] = value
+			if key == "options" || key == "opts" {
+				m.options = append(m.options, strings.Split(value, ",")...)
+			}
+		} else if i == 0 {
+			style := attr
+			if idx := strings.IndexAny(style, "#.%"); idx >= 0 {
+				for _, opt := range strings.Split(style[idx:], "%")[1:] {
+					if j := strings.IndexAny(opt, "#."); j >= 0 {
+						opt = opt[:j]
+					}
+					m.options = append(m.options, opt)
+				}
+				style = style[:idx]
+			}
+			if style != "" {
+				m.style = style
+			}
+		}
+	}
+}
+
+// splitAttrs splits an attribute list on commas, respecting quotes.
+func splitAttrs(list string) []string {
+	attrs := []string{}
+
+	quote, start := rune(0), 0
+	for i, r := range list {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && r == ',':
+			attrs = append(attrs, strings.TrimSpace(list[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(attrs, strings.TrimSpace(list[start:]))
+}
+
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > 1 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func admonition(style string) string {
+	return "<div class=\"admonitionblock " + strings.ToLower(style) + "\">\n"
+}
+
+func isAdmonition(style string) bool {
+	for _, name := range admonitions {
+		if style == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isLiteralStyle(style string) bool {
+	return style == "listing" || style == "literal" || style == "source"
+}
+
+func isMath(style string) bool {
+	return style == "stem" || style == "latexmath" || style == "asciimath"
+}
+
+// isComment reports whether `line` is a single-line comment.
+func isComment(line string) bool {
+	return strings.HasPrefix(line, "//") && !strings.HasPrefix(line, "///")
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+func dedent(lines []string) []string {
+	min := -1
+	for _, l := range lines {
+		if n := indentOf(l); !isBlank(l) && (min < 0 || n < min) {
+			min = n
+		}
+	}
+
+	block := make([]string, len(lines))
+	for i, l := range lines {
+		if len(l) >= min && min > 0 {
+			block[i] = l[min:]
+		} else {
+			block[i] = strings.TrimLeft(l, " \t")
+		}
+	}
+
+	return block
+}
+
+func splitLines(src string) []string {
+	src = strings.Replace(src, "\r\n", "\n", -1)
+
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	return lines
+}
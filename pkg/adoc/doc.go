@@ -0,0 +1,2 @@
+// Package adoc implements a pure-Go converter from AsciiDoc to HTML.
+package adoc
@@ -0,0 +1,270 @@
+package adoc
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var reInline = regexp.MustCompile(`(?s)` + strings.Join([]string{
+	`\\(?P<escaped>[*_` + "`" + `#^~+\[<{]|(?:pass|image|link|xref|footnote|kbd|btn|menu|mailto|https?|ftp):)`,
+	`pass:[a-z,]*\[(?P<pass>(?:[^\]\\]|\\.)*)\]`,
+	`\+\+\+(?P<raw>.+?)\+\+\+`,
+	`\+\+(?P<plain2>.+?)\+\+`,
+	`\+(?P<plain>[^\s+](?:.*?[^\s+])?)\+`,
+	"``(?P<code2>.+?)``",
+	"`(?P<code>[^\\s`](?:.*?[^\\s`])?)`",
+	`\*\*(?P<strong2>.+?)\*\*`,
+	`\*(?P<strong>[^\s*](?:.*?[^\s*])?)\*`,
+	`__(?P<em2>.+?)__`,
+	`_(?P<em>[^\s_](?:.*?[^\s_])?)_`,
+	`##(?P<mark2>.+?)##`,
+	`#(?P<mark>[^\s#](?:.*?[^\s#])?)#`,
+	`\^(?P<sup>[^\s^]+)\^`,
+	`~(?P<sub>[^\s~]+)~`,
+	`image:(?P<image>[^\s\[:][^\s\[]*)\[(?P<imageattrs>[^\]]*)\]`,
+	`footnote(?:ref)?:[\w-]*\[(?P<footnote>(?:[^\]\\]|\\.)*)\]`,
+	`(?:kbd|btn):\[(?P<kbd>(?:[^\]\\]|\\.)*)\]`,
+	`menu:(?P<menu>\w[^\[\n]*)\[(?P<menuitems>[^\]]*)\]`,
+	`xref:(?P<xref>[^\s\[]+)\[(?P<xreftext>[^\]]*)\]`,
+	`<<(?P<ref>[^,>\s]+)(?:,\s*(?P<reftext>[^>]+))?>>`,
+	`(?:link|mailto):(?P<link>[^\s\[]+)\[(?P<linktext>[^\]]*)\]`,
+	`<(?P<angled>(?:https?|ftp|irc)://[^\s>]+)>`,
+	`(?P<url>(?:https?|ftp|irc)://[^\s\[\]<>]*[^\s\[\]<>.,;:!?)'"])(?:\[(?P<urltext>[^\]]*)\])?`,
+	`(?P<anchor>\[\[\[?[\w:.-]+(?:,[^\]]*)?\]\]\]?|anchor:[\w:.-]+\[[^\]]*\])`,
+	`\(\(\((?P<index>.+?)\)\)\)`,
+	`\(\((?P<term>.+?)\)\)`,
+}, "|"))
+
+var reAttrRef = regexp.MustCompile(`\\?\{(\w[\w-]*)(?::[^}]*)?\}`)
+var reHardBreak = regexp.MustCompile(`(?m) \+$`)
+var reEscape = regexp.MustCompile(`\\([*_` + "`" + `#^~+\[\]<>{}\\])`)
+
+// constrained are the groups that are only markup at word boundaries (e.g.,
+// "*bold*" but not "2*3*4").
+var constrained = []string{"plain", "code", "strong", "em", "mark"}
+
+// builtins are Asciidoctor's predefined character-replacement attributes.
+var builtins = map[string]string{
+	"amp":            "&",
+	"apos":           "'",
+	"asterisk":       "*",
+	"backslash":      "\\",
+	"backtick":       "`",
+	"blank":          "",
+	"caret":          "^",
+	"cpp":            "C++",
+	"empty":          "",
+	"endsb":          "]",
+	"gt":             ">",
+	"lt":             "<",
+	"nbsp":           "\u00a0",
+	"plus":           "+",
+	"quot":           "\"",
+	"sp":             " ",
+	"startsb":        "[",
+	"tilde":          "~",
+	"two-colons":     "::",
+	"two-semicolons": ";;",
+	"vbar":           "|",
+	"wj":             "\u2060",
+	"zwsp":           "\u200b",
+}
+
+// inline converts the inline markup in `text` into HTML.
+func (p *parser) inline(text string) string {
+	return p.spans(reHardBreak.ReplaceAllString(p.substitute(text), ""))
+}
+
+// spans converts the inline markup in `text`, which has had its attribute
+// references replaced.
+func (p *parser) spans(text string) string {
+	var b strings.Builder
+
+	names := reInline.SubexpNames()
+	for len(text) > 0 {
+		loc := reInline.FindStringSubmatchIndex(text)
+		if loc == nil {
+			b.WriteString(plain(text))
+			break
+		}
+
+		groups := make(map[string]string)
+		for i, name := range names {
+			if name != "" && loc[2*i] >= 0 {
+				groups[name] = text[loc[2*i]:loc[2*i+1]]
+			}
+		}
+
+		start, end := loc[0], loc[1]
+		if isConstrained(groups) && (!startsMarkup(text, start) || !endsMarkup(text, end)) {
+			// Not actually markup, so we move past the start-string.
+			_, size := utf8.DecodeRuneInString(text[start:])
+			b.WriteString(plain(text[:start+size]))
+			text = text[start+size:]
+			continue
+		}
+
+		b.WriteString(plain(text[:start]))
+		b.WriteString(p.markup(groups))
+
+		text = text[end:]
+	}
+
+	return b.String()
+}
+
+func (p *parser) markup(groups map[string]string) string {
+	for _, name := range []string{"plain", "plain2", "term"} {
+		if s, ok := groups[name]; ok {
+			return html.EscapeString(s)
+		}
+	}
+
+	for _, name := range []string{"code", "code2"} {
+		if s, ok := groups[name]; ok {
+			s = strings.TrimSuffix(strings.TrimPrefix(s, "+"), "+")
+			return wrap("code", html.EscapeString(s))
+		}
+	}
+
+	for name, tag := range map[string]string{
+		"strong": "strong", "strong2": "strong",
+		"em": "em", "em2": "em",
+		"mark": "mark", "mark2": "mark",
+		"sup": "sup", "sub": "sub"} {
+		if s, ok := groups[name]; ok {
+			return wrap(tag, p.spans(s))
+		}
+	}
+
+	if s, ok := groups["escaped"]; ok {
+		return html.EscapeString(s)
+	} else if s, ok := groups["pass"]; ok {
+		return s
+	} else if s, ok := groups["raw"]; ok {
+		return s
+	} else if s, ok := groups["image"]; ok {
+		return p.image([]string{s, groups["imageattrs"]})
+	} else if s, ok := groups["footnote"]; ok {
+		p.footnotes = append(p.footnotes, s)
+		return "<sup>[" + strconv.Itoa(len(p.footnotes)) + "]</sup>"
+	} else if s, ok := groups["kbd"]; ok {
+		return wrap("kbd", html.EscapeString(s))
+	} else if s, ok := groups["menu"]; ok {
+		return wrap("span", plain(s)+" "+plain(groups["menuitems"]))
+	} else if s, ok := groups["xref"]; ok {
+		return link("#"+s, p.linkText(groups["xreftext"], ""))
+	} else if s, ok := groups["ref"]; ok {
+		return link("#"+s, p.linkText(groups["reftext"], ""))
+	} else if s, ok := groups["link"]; ok {
+		return link(s, p.linkText(groups["linktext"], html.EscapeString(s)))
+	} else if s, ok := groups["angled"]; ok {
+		return link(s, html.EscapeString(s))
+	} else if s, ok := groups["url"]; ok {
+		return link(s, p.linkText(groups["urltext"], html.EscapeString(s)))
+	}
+
+	// An anchor or a hidden index term.
+	return ""
+}
+
+// linkText converts a link's text, which may include attributes (e.g.,
+// `text,window=_blank` or `text^`).
+func (p *parser) linkText(text, fallback string) string {
+	attrs := splitAttrs(text)
+	if text = unquote(attrs[0]); strings.Contains(text, "=") {
+		text = ""
+	}
+
+	text = strings.TrimSuffix(text, "^")
+	if text == "" {
+		return fallback
+	}
+	return p.spans(text)
+}
+
+// image converts an image macro's target and attributes.
+func (p *parser) image(m []string) string {
+	alt := ""
+	for i, attr := range splitAttrs(m[1]) {
+		if parts := strings.SplitN(attr, "=", 2); len(parts) == 2 {
+			if strings.TrimSpace(parts[0]) == "alt" {
+				alt = unquote(parts[1])
+			}
+		} else if i == 0 {
+			alt = unquote(attr)
+		}
+	}
+
+	img := "<img src=\"" + html.EscapeString(m[0]) + "\""
+	if alt != "" {
+		img += " alt=\"" + html.EscapeString(alt) + "\""
+	}
+	return img + "/>"
+}
+
+// substitute replaces attribute references (e.g., `{product}`) with their
+// values, dropping any that are undefined.
+func (p *parser) substitute(text string) string {
+	return reAttrRef.ReplaceAllStringFunc(text, func(m string) string {
+		if strings.HasPrefix(m, "\\") {
+			return m[1:]
+		}
+
+		name := reAttrRef.FindStringSubmatch(m)[1]
+		if value, found := p.attrs[name]; found {
+			return value
+		}
+		return builtins[name]
+	})
+}
+
+func isConstrained(groups map[string]string) bool {
+	for _, name := range constrained {
+		if _, ok := groups[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func link(href, text string) string {
+	return `<a href="` + html.EscapeString(href) + `">` + text + `</a>`
+}
+
+// plain escapes `text`, removing any backslash escapes.
+func plain(text string) string {
+	return html.EscapeString(reEscape.ReplaceAllString(text, "$1"))
+}
+
+func wrap(tag, s string) string {
+	return "<" + tag + ">" + s + "</" + tag + ">"
+}
+
+// startsMarkup reports whether constrained markup may start at `text[i]`: it
+// must not follow a word character.
+func startsMarkup(text string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:i])
+	return !isWord(r) && !strings.ContainsRune(`;:}`, r)
+}
+
+// endsMarkup reports whether constrained markup may end at `text[i]`: it must
+// not be followed by a word character.
+func endsMarkup(text string, i int) bool {
+	if i == len(text) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return !isWord(r)
+}
+
+func isWord(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,143 @@
+package adoc
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	reListItem = regexp.MustCompile(`^[ \t]*(-|\*{1,5}|\.{1,5}|\d+\.|[a-zA-Z]\.|[ivxIVX]+\)|<(?:\d+|\.)>)[ \t]+(.*)$`)
+	reDescItem = regexp.MustCompile(`^[ \t]*(.*?[^:;\s])(:{2,4}|;;)(?:[ \t]+(.*))?$`)
+	reChecked  = regexp.MustCompile(`^\[[ xX*]\][ \t]+`)
+)
+
+// item is a list item's marker line.
+type item struct {
+	marker string // the normalized marker (e.g., "1." for any number)
+	tag    string // the list's tag: "ul", "ol", or "dl"
+	term   string // a description list's term
+	text   string
+}
+
+// listItem returns the list item that starts at `line`, if any.
+func listItem(line string) *item {
+	if m := reListItem.FindStringSubmatch(line); m != nil {
+		marker, tag := m[1], "ol"
+		switch {
+		case marker == "-" || marker[0] == '*':
+			tag = "ul"
+		case marker[0] == '<':
+			marker = "<1>"
+		case strings.HasSuffix(marker, ")"):
+			marker = "i)"
+		case len(marker) > 1 && marker[0] >= '0' && marker[0] <= '9':
+			marker = "1."
+		case len(marker) == 2 && marker[0] >= 'a' && marker[0] <= 'z':
+			marker = "a."
+		case len(marker) == 2 && marker[0] >= 'A' && marker[0] <= 'Z':
+			marker = "A."
+		}
+		return &item{marker: marker, tag: tag, text: reChecked.ReplaceAllString(m[2], "")}
+	} else if m := reDescItem.FindStringSubmatch(line); m != nil && !isComment(line) {
+		return &item{marker: m[2], tag: "dl", term: m[1], text: m[3]}
+	}
+	return nil
+}
+
+// list converts the list starting at `lines[i]`, returning the index of the
+// first line after it. `parents` are the markers of any enclosing lists.
+func (p *parser) list(lines []string, i int, parents []string) int {
+	first := listItem(lines[i])
+	parents = append(parents, first.marker)
+
+	p.out.WriteString("<" + first.tag + ">\n")
+	for i < len(lines) {
+		it := listItem(lines[i])
+		if it == nil || it.marker != first.marker {
+			break
+		}
+
+		// The item's principal text continues until a blank line, a list
+		// continuation, another item, or the start of another block.
+		text := []string{}
+		if it.text != "" {
+			text = append(text, it.text)
+		}
+		for i++; i < len(lines) && !isBlank(lines[i]); i++ {
+			line := lines[i]
+			if line == "+" || listItem(line) != nil || reDelimiter.MatchString(line) ||
+				reTable.MatchString(line) || reBlockAttrs.MatchString(line) {
+				break
+			}
+			text = append(text, line)
+		}
+
+		if first.tag == "dl" {
+			p.out.WriteString("<dt>" + p.inline(it.term) + "</dt>\n<dd>")
+		} else {
+			p.out.WriteString("<li>")
+		}
+		if len(text) > 0 {
+			p.out.WriteString(p.para(text))
+		}
+
+		i = p.attached(lines, i, first.tag, parents)
+
+		if first.tag == "dl" {
+			p.out.WriteString("</dd>\n")
+		} else {
+			p.out.WriteString("</li>\n")
+		}
+	}
+	p.out.WriteString("</" + first.tag + ">\n")
+
+	return i
+}
+
+// attached converts the blocks attached to a list item -- nested lists,
+// literal paragraphs, and blocks following a list continuation ("+") --
+// returning the index of the first line after them.
+func (p *parser) attached(lines []string, i int, tag string, parents []string) int {
+	inList := p.inList
+	defer func() { p.inList = inList }()
+
+	for i < len(lines) {
+		j := i
+		for j < len(lines) && isBlank(lines[j]) {
+			j++
+		}
+		if j == len(lines) {
+			return i
+		}
+
+		line := lines[j]
+		if line == "+" && j == i {
+			p.inList = true
+			i = p.block(lines, j+1)
+		} else if it := listItem(line); it != nil {
+			for _, marker := range parents {
+				if it.marker == marker {
+					// A sibling of this item or one of its parents.
+					return j
+				}
+			}
+			i = p.list(lines, j, parents)
+		} else if indentOf(line) > 0 {
+			end := j
+			for end < len(lines) && !isBlank(lines[end]) {
+				end++
+			}
+			if tag == "dl" {
+				// A description that starts on the line after its term.
+				p.out.WriteString(p.para(lines[j:end]))
+			} else {
+				p.literal(dedent(lines[j:end]))
+			}
+			i = end
+		} else {
+			return i
+		}
+	}
+
+	return i
+}
@@ -0,0 +1,274 @@
+package adoc
+
+import (
+	"encoding/csv"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var reCellSpec = regexp.MustCompile(`^(?:(\d+)\*)?(?:(\d+)?(?:\.\d+)?\+)?[<^>]?(?:\.[<^>])?([adehlmsv])?$`)
+var reRepeat = regexp.MustCompile(`^(\d+)\*`)
+
+// cell is a table cell.
+type cell struct {
+	text  string
+	style string // e.g., "a" for AsciiDoc content or "l" for literal
+	span  int    // the number of columns the cell spans
+	count int    // the number of times the cell is repeated (e.g., `3*|`)
+	line  int    // the index of the line the cell starts on
+}
+
+// table converts a table (e.g., `|===` or `,===`), returning the index of
+// the first line after it.
+func (p *parser) table(lines []string, i int, meta blockMeta) int {
+	delim := lines[i]
+
+	end := i + 1
+	for end < len(lines) && lines[end] != delim {
+		end++
+	}
+	content := lines[i+1 : end]
+
+	format := meta.named["format"]
+	if format == "" {
+		format = map[byte]string{',': "csv", ':': "dsv"}[delim[0]]
+	}
+
+	var cells []cell
+	switch format {
+	case "csv", "tsv":
+		cells = csvCells(content, format)
+	case "dsv":
+		cells = dsvCells(content)
+	default:
+		sep := delim[:1]
+		if s := meta.named["separator"]; s != "" {
+			sep = s
+		}
+		cells = psvCells(content, sep)
+	}
+
+	if len(cells) == 0 {
+		return end + 1
+	}
+
+	ncols := columns(meta.named["cols"])
+	if ncols == 0 {
+		for _, c := range cells {
+			if c.line == cells[0].line {
+				ncols += c.span
+			}
+		}
+	}
+
+	// The first row is a header if it's on a line of its own and followed by
+	// a blank line (or if the table has the `header` option).
+	header := meta.hasOption("header") || (len(content) > 1 &&
+		cells[0].line == 0 && isBlank(content[1]))
+	if meta.hasOption("noheader") {
+		header = false
+	}
+
+	p.out.WriteString("<table>\n")
+	if meta.title != "" {
+		p.out.WriteString("<caption class=\"title\">" + p.inline(meta.title) + "</caption>\n")
+	}
+
+	width, row := 0, 0
+	for _, c := range cells {
+		if width == 0 {
+			p.out.WriteString("<tr>")
+		}
+
+		tag := "td"
+		if (header && row == 0) || c.style == "h" {
+			tag = "th"
+		}
+		p.out.WriteString("<" + tag + ">")
+		p.cell(c)
+		p.out.WriteString("</" + tag + ">")
+
+		width += c.span
+		if width >= ncols {
+			p.out.WriteString("</tr>\n")
+			width = 0
+			row++
+		}
+	}
+	if width > 0 {
+		p.out.WriteString("</tr>\n")
+	}
+	p.out.WriteString("</table>\n")
+
+	return end + 1
+}
+
+// cell converts a cell's content according to its style.
+func (p *parser) cell(c cell) {
+	switch c.style {
+	case "a":
+		p.body(splitLines(c.text))
+	case "l":
+		p.literal(splitLines(c.text))
+	case "m":
+		p.out.WriteString("<p>" + wrap("code", plain(c.text)) + "</p>\n")
+	default:
+		para := []string{}
+		for _, line := range append(splitLines(c.text), "") {
+			if !isBlank(line) {
+				para = append(para, line)
+			} else if len(para) > 0 {
+				p.out.WriteString(p.para(para))
+				para = []string{}
+			}
+		}
+	}
+}
+
+// psvCells splits the content of a table that uses prefix-separated values:
+// each cell starts with `sep`, optionally preceded by a cell specifier
+// (e.g., `2+|` or `a|`).
+func psvCells(content []string, sep string) []cell {
+	var cells []cell
+	var cur *cell
+
+	spec := ""
+	for n, line := range content {
+		segments := splitUnescaped(line, sep)
+		for k, segment := range segments {
+			if k == 0 {
+				if cur != nil {
+					cur.text += "\n" + segment
+				} else {
+					spec = strings.TrimSpace(segment)
+				}
+				continue
+			}
+
+			if cur != nil {
+				cur.text, spec = splitSpec(cur.text)
+				cells = append(cells, expand(*cur)...)
+			}
+
+			c := newCell(spec, n)
+			c.text = segment
+			cur = &c
+		}
+	}
+
+	if cur != nil {
+		cells = append(cells, expand(*cur)...)
+	}
+	return cells
+}
+
+// csvCells splits the content of a CSV (or TSV) table.
+func csvCells(content []string, format string) []cell {
+	r := csv.NewReader(strings.NewReader(strings.Join(content, "\n")))
+	r.LazyQuotes = true
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+	if format == "tsv" {
+		r.Comma = '\t'
+	}
+
+	var cells []cell
+
+	records, _ := r.ReadAll()
+	for n, record := range records {
+		for _, field := range record {
+			cells = append(cells, cell{text: field, span: 1, line: n})
+		}
+	}
+
+	return cells
+}
+
+// dsvCells splits the content of a table that uses delimiter-separated
+// values (`:===`).
+func dsvCells(content []string) []cell {
+	var cells []cell
+	for n, line := range content {
+		if isBlank(line) {
+			continue
+		}
+		for _, field := range splitUnescaped(line, ":") {
+			cells = append(cells, cell{text: strings.TrimSpace(field), span: 1, line: n})
+		}
+	}
+	return cells
+}
+
+// splitSpec separates a trailing cell specifier from `text`.
+func splitSpec(text string) (string, string) {
+	idx := strings.LastIndexAny(text, " \t\n")
+	if idx < 0 {
+		return strings.TrimSpace(text), ""
+	}
+
+	token := text[idx+1:]
+	if token != "" && reCellSpec.MatchString(token) {
+		return strings.TrimSpace(text[:idx]), token
+	}
+	return strings.TrimSpace(text), ""
+}
+
+func newCell(spec string, line int) cell {
+	c := cell{span: 1, count: 1, line: line}
+	if m := reCellSpec.FindStringSubmatch(spec); m != nil && spec != "" {
+		if n, err := strconv.Atoi(m[2]); err == nil {
+			c.span = n
+		}
+		c.style = m[3]
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			c.count = n
+		}
+	}
+	return c
+}
+
+// expand finalizes a cell, duplicating it if it's repeated.
+func expand(c cell) []cell {
+	c.text = strings.TrimSpace(c.text)
+
+	if c.count < 1 {
+		c.count = 1
+	}
+
+	cells := make([]cell, c.count)
+	for i := range cells {
+		cells[i] = c
+	}
+	return cells
+}
+
+// columns returns the number of columns given by a `cols` attribute (e.g.,
+// "3*" or "1,2,1").
+func columns(cols string) int {
+	cols = strings.TrimSpace(cols)
+	if m := reRepeat.FindStringSubmatch(cols); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return n
+	}
+	return len(strings.FieldsFunc(cols, func(r rune) bool {
+		return r == ',' || r == ';'
+	}))
+}
+
+// splitUnescaped splits `line` on `sep`, ignoring any escaped separators.
+func splitUnescaped(line, sep string) []string {
+	parts := strings.Split(line, sep)
+
+	segments := []string{parts[0]}
+	for _, part := range parts[1:] {
+		last := segments[len(segments)-1]
+		if strings.HasSuffix(last, "\\") {
+			segments[len(segments)-1] = last[:len(last)-1] + sep + part
+		} else {
+			segments = append(segments, part)
+		}
+	}
+
+	return segments
+}
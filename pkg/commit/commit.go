@@ -0,0 +1,117 @@
+package commit
+
+import (
+	"strings"
+
+	"github.com/jdkato/regexp"
+)
+
+// Scissors is the line below which Git ignores a message's content -- e.g.,
+// the diff added by `git commit --verbose`.
+const Scissors = "# ------------------------ >8 ------------------------"
+
+var reTrailer = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*:(?:\s|$)`)
+
+// A Part is the subject, body, or trailer of a commit message.
+type Part struct {
+	Text  string // the part's content, without any comment lines
+	Lines []int  // the (1-based) line in the message of each line of Text
+}
+
+// Locate maps a (1-based) line in p's Text to its line in the message.
+func (p Part) Locate(line int) int {
+	if line < 1 || line > len(p.Lines) {
+		return line
+	}
+	return p.Lines[line-1]
+}
+
+// A Message is a commit message.
+type Message struct {
+	Subject Part
+	Body    Part
+	Trailer Part
+}
+
+// line is a line of a message that isn't a comment.
+type line struct {
+	text string
+	n    int // the line's (1-based) number in the message
+}
+
+// Parse splits the commit message `src` into its parts.
+//
+// As in Git's default (`strip`) cleanup mode, lines that start with '#' are
+// ignored, as is everything from the scissors line onward. The subject is
+// the message's first paragraph and the trailer is its last paragraph
+// (other than the subject), provided that each of its lines is a
+// `Key: value` pair or the indented continuation of one. Everything in
+// between is the body.
+func Parse(src string) Message {
+	lines := []line{}
+	for i, l := range strings.Split(src, "\n") {
+		l = strings.TrimSuffix(l, "\r")
+		if l == Scissors {
+			break
+		} else if !strings.HasPrefix(l, "#") {
+			lines = append(lines, line{text: l, n: i + 1})
+		}
+	}
+
+	// The [start, end) range of each paragraph in `lines`.
+	paras := [][2]int{}
+	for i := 0; i < len(lines); i++ {
+		if isBlank(lines[i]) {
+			continue
+		}
+		start := i
+		for i < len(lines) && !isBlank(lines[i]) {
+			i++
+		}
+		paras = append(paras, [2]int{start, i})
+	}
+
+	msg := Message{}
+	if len(paras) == 0 {
+		return msg
+	}
+
+	msg.Subject = part(lines[paras[0][0]:paras[0][1]])
+	paras = paras[1:]
+
+	if n := len(paras); n > 0 && isTrailer(lines[paras[n-1][0]:paras[n-1][1]]) {
+		msg.Trailer = part(lines[paras[n-1][0]:paras[n-1][1]])
+		paras = paras[:n-1]
+	}
+
+	if n := len(paras); n > 0 {
+		msg.Body = part(lines[paras[0][0]:paras[n-1][1]])
+	}
+
+	return msg
+}
+
+func part(lines []line) Part {
+	p := Part{}
+	texts := []string{}
+	for _, l := range lines {
+		texts = append(texts, l.text)
+		p.Lines = append(p.Lines, l.n)
+	}
+	p.Text = strings.Join(texts, "\n")
+	return p
+}
+
+func isBlank(l line) bool {
+	return strings.TrimSpace(l.text) == ""
+}
+
+func isTrailer(lines []line) bool {
+	for i, l := range lines {
+		continued := i > 0 && (strings.HasPrefix(l.text, " ") || strings.HasPrefix(l.text, "\t"))
+		if !continued && !reTrailer.MatchString(l.text) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package commit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	msg := Parse(`Add a commit message format
+
+# A comment in the body.
+This is the first paragraph
+of the body.
+
+This is the second.
+
+Signed-off-by: A U Thor <author@example.com>
+Co-authored-by: Someone Else
+  <someone@example.com>
+# Please enter the commit message for your changes.
+#
+# ------------------------ >8 ------------------------
+# Do not modify or remove the line above.
+diff --git a/README.md b/README.md
++Fixes: this isn't a trailer.
+`)
+
+	for name, c := range map[string]struct {
+		p     Part
+		text  string
+		lines []int
+	}{
+		"subject": {msg.Subject, "Add a commit message format", []int{1}},
+		"body": {msg.Body, "This is the first paragraph\nof the body.\n\nThis is the second.",
+			[]int{4, 5, 6, 7}},
+		"trailer": {msg.Trailer,
+			"Signed-off-by: A U Thor <author@example.com>\nCo-authored-by: Someone Else\n  <someone@example.com>",
+			[]int{9, 10, 11}},
+	} {
+		if c.p.Text != c.text {
+			t.Errorf("%s: expected %q, got %q", name, c.text, c.p.Text)
+		}
+		if !reflect.DeepEqual(c.p.Lines, c.lines) {
+			t.Errorf("%s: expected lines %v, got %v", name, c.lines, c.p.Lines)
+		}
+	}
+
+	if line := msg.Body.Locate(2); line != 5 {
+		t.Errorf("expected line 5, got %d", line)
+	}
+}
+
+func TestParseWithoutTrailer(t *testing.T) {
+	msg := Parse("\n\nFix a typo.\n\nNote that this isn't a trailer\nsince it spans two lines.\n")
+	if msg.Subject.Text != "Fix a typo." || msg.Subject.Lines[0] != 3 {
+		t.Errorf("unexpected subject: %+v", msg.Subject)
+	}
+	if msg.Body.Text != "Note that this isn't a trailer\nsince it spans two lines." {
+		t.Errorf("unexpected body: %+v", msg.Body)
+	}
+	if msg.Trailer.Text != "" {
+		t.Errorf("unexpected trailer: %+v", msg.Trailer)
+	}
+
+	// A message's subject is never a trailer.
+	msg = Parse("Fixes: #123\n")
+	if msg.Subject.Text != "Fixes: #123" || msg.Trailer.Text != "" {
+		t.Errorf("unexpected parts: %+v", msg)
+	}
+
+	if msg = Parse("# Only comments.\n\n"); msg.Subject.Text != "" {
+		t.Errorf("expected an empty message, got %+v", msg)
+	}
+}
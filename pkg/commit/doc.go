@@ -0,0 +1,2 @@
+// Package commit parses Git commit messages.
+package commit
@@ -0,0 +1,227 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// A Loc is a location in a data file.
+type Loc struct {
+	Line   int // the (1-based) line
+	Column int // the number of characters that precede the location
+}
+
+// A Value is a string value in a data file.
+type Value struct {
+	Path  []string // the keys (and array indices) that lead to the value
+	Text  string   // the value's content
+	Lines []Loc    // the location of each line of Text in the file
+
+	// columns holds the column (in the file) of each character of each line
+	// of Text, which accounts for escape sequences.
+	columns [][]int
+}
+
+// Locate maps a line and column (both 1-based) in v's Text to their
+// location in the file.
+func (v Value) Locate(line, col int) (int, int) {
+	if line < 1 || line > len(v.Lines) {
+		return line, col
+	}
+
+	loc := v.Lines[line-1]
+	if line <= len(v.columns) {
+		if cols := v.columns[line-1]; col >= 1 && col <= len(cols) {
+			return loc.Line, cols[col-1] + 1
+		} else if col > len(cols) && len(cols) > 0 {
+			return loc.Line, cols[len(cols)-1] + 1 + col - len(cols)
+		}
+	}
+
+	return loc.Line, loc.Column + col
+}
+
+// Parse extracts the string values of `src`, whose format is given by its
+// (normalized) extension: ".json", ".yml", or ".toml".
+//
+// The parsers are position-preserving rather than complete: YAML flow
+// collections, aliases, and complex keys are skipped, as are comments and
+// values of any other type (numbers, booleans, dates, etc.).
+func Parse(src, ext string) ([]Value, error) {
+	d := newDoc(src)
+
+	var err error
+	switch ext {
+	case ".json":
+		err = parseJSON(d)
+	case ".yml":
+		err = parseYAML(d)
+	case ".toml":
+		err = parseTOML(d)
+	default:
+		err = fmt.Errorf("unsupported format '%s'", ext)
+	}
+
+	return d.values, err
+}
+
+// Match reports whether `path` matches `pattern`, a dot-separated list of
+// keys in which `*` matches any single key (or array index) and `**` matches
+// any number of them -- e.g., `paths.*.*.summary` or `**.description`.
+func Match(pattern string, path []string) bool {
+	return match(strings.Split(strings.TrimSpace(pattern), "."), path)
+}
+
+func match(parts, path []string) bool {
+	if len(parts) == 0 {
+		return len(path) == 0
+	}
+
+	switch part := strings.TrimSpace(parts[0]); {
+	case part == "**":
+		for i := 0; i <= len(path); i++ {
+			if match(parts[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	case len(path) == 0:
+		return false
+	case part == "*" || part == path[0]:
+		return match(parts[1:], path[1:])
+	}
+
+	return false
+}
+
+// doc is a data file being parsed.
+type doc struct {
+	src    string
+	lines  []int // the offset of each line in src
+	values []Value
+	last   Loc // the location of the byte at offset `off`
+	off    int
+}
+
+func newDoc(src string) *doc {
+	d := doc{src: src, lines: []int{0}}
+	for i := 0; i < len(src); i++ {
+		if src[i] == '\n' {
+			d.lines = append(d.lines, i+1)
+		}
+	}
+	return &d
+}
+
+// loc returns the location of the byte at offset `i`.
+//
+// Since we mostly read forward, we count from the last location we returned
+// when possible.
+func (d *doc) loc(i int) Loc {
+	n := sort.Search(len(d.lines), func(j int) bool { return d.lines[j] > i })
+	if n == d.last.Line && i >= d.off {
+		d.last.Column += utf8.RuneCountInString(d.src[d.off:i])
+	} else {
+		d.last = Loc{Line: n, Column: utf8.RuneCountInString(d.src[d.lines[n-1]:i])}
+	}
+	d.off = i
+	return d.last
+}
+
+// line returns the (1-based) line of the byte at offset `i`.
+func (d *doc) line(i int) int {
+	return d.loc(i).Line
+}
+
+// builder accumulates the content of a value while recording the location
+// of each of its lines (and characters).
+type builder struct {
+	d       *doc
+	text    strings.Builder
+	lines   []Loc
+	columns [][]int
+	open    bool // has the current line been located?
+}
+
+// add appends `s`, which was read from offset `i`.
+func (b *builder) add(s string, i int) {
+	loc := b.d.loc(i)
+	if !b.open {
+		b.lines = append(b.lines, loc)
+		b.columns = append(b.columns, nil)
+		b.open = true
+	}
+
+	cols := &b.columns[len(b.columns)-1]
+	for range s {
+		*cols = append(*cols, loc.Column)
+	}
+	b.text.WriteString(s)
+}
+
+// newline ends the current line, which was read up to offset `i`.
+func (b *builder) newline(i int) {
+	if !b.open {
+		b.lines = append(b.lines, b.d.loc(i))
+		b.columns = append(b.columns, nil)
+	}
+	b.text.WriteByte('\n')
+	b.open = false
+}
+
+func (b *builder) value(path []string) Value {
+	return Value{Path: path, Text: b.text.String(), Lines: b.lines, columns: b.columns}
+}
+
+// unescape decodes the backslash escape at offset `i` of `s`, returning its
+// value and length.
+func unescape(s string, i int) (string, int) {
+	if i+1 >= len(s) {
+		return "\\", 1
+	}
+
+	switch c := s[i+1]; c {
+	case 'b':
+		return "\b", 2
+	case 'f':
+		return "\f", 2
+	case 'n':
+		return "\n", 2
+	case 'r':
+		return "\r", 2
+	case 't':
+		return "\t", 2
+	case '0':
+		return "\x00", 2
+	case 'e':
+		return "\x1b", 2
+	case 'x', 'u', 'U':
+		size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[c]
+		if i+2+size > len(s) {
+			break
+		}
+		r, err := strconv.ParseUint(s[i+2:i+2+size], 16, 32)
+		if err != nil {
+			break
+		}
+		n := 2 + size
+		if utf16.IsSurrogate(rune(r)) && strings.HasPrefix(s[i+n:], "\\u") && i+n+6 <= len(s) {
+			if lo, err := strconv.ParseUint(s[i+n+2:i+n+6], 16, 32); err == nil {
+				return string(utf16.DecodeRune(rune(r), rune(lo))), n + 6
+			}
+		}
+		return string(rune(r)), n
+	}
+
+	r, n := utf8.DecodeRuneInString(s[i+1:])
+	return string(r), n + 1
+}
+
+// appendKey returns a copy of `path` with `key` appended.
+func appendKey(path []string, key string) []string {
+	return append(path[:len(path):len(path)], key)
+}
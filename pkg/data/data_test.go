@@ -0,0 +1,154 @@
+package data
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// flatten formats each value as "path=text@line:column,...".
+func flatten(values []Value) []string {
+	found := []string{}
+	for _, v := range values {
+		locs := []string{}
+		for _, l := range v.Lines {
+			locs = append(locs, fmt.Sprintf("%d:%d", l.Line, l.Column))
+		}
+		found = append(found, strings.Join(v.Path, ".")+"="+v.Text+"@"+strings.Join(locs, ","))
+	}
+	return found
+}
+
+var parseTests = []struct {
+	ext      string
+	src      string
+	expected []string
+}{
+	{".json", `{
+  "info": {"title": "API", "description": "Line one.\nLine \"two\"."},
+  "tags": ["a", 1, true, null],
+  "n": 1.5
+}`, []string{
+		"info.title=API@2:21",
+		"info.description=Line one.\nLine \"two\".@2:43,2:54",
+		"tags.0=a@3:12",
+	}},
+	{".yml", `# A comment.
+info:
+  title: My API  # trailing
+  version: 1.0
+  description: |
+    First line.
+
+    Second line.
+paths:
+  /users:
+    get:
+      summary: 'It''s here'
+      tags:
+      - users
+      - "more \"users\""
+list:
+- name: One
+  note: >-
+    Folded
+    text.
+- &anchor plain
+  continued
+- [flow, ignored]
+`, []string{
+		"info.title=My API@3:9",
+		"info.description=First line.\n\nSecond line.@6:4,7:0,8:4",
+		"paths./users.get.summary=It's here@12:16",
+		"paths./users.get.tags.0=users@14:8",
+		"paths./users.get.tags.1=more \"users\"@15:9",
+		"list.0.name=One@17:8",
+		"list.0.note=Folded\ntext.@19:4,20:4",
+		"list.1=plain\ncontinued@21:10,22:2",
+	}},
+	{".toml", `title = "Doc" # comment
+n = 1
+
+[server]
+"quoted key" = 'C:\path'
+note = """
+Multi-line \
+  string."""
+tags = ["a", { name = "b" }]
+
+[[items]]
+text = "One"
+
+[[items]]
+text = "Two"
+
+[items.meta]
+info = '''x'''
+`, []string{
+		"title=Doc@1:9",
+		"server.quoted key=C:\\path@5:16",
+		"server.note=Multi-line string.@7:0",
+		"server.tags.0=a@9:9",
+		"server.tags.1.name=b@9:23",
+		"items.0.text=One@12:8",
+		"items.1.text=Two@15:8",
+		"items.1.meta.info=x@18:10",
+	}},
+}
+
+func TestParse(t *testing.T) {
+	for _, tt := range parseTests {
+		values, err := Parse(tt.src, tt.ext)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.ext, err)
+		}
+		if found := flatten(values); !reflect.DeepEqual(found, tt.expected) {
+			t.Errorf("%s: expected\n%q, got\n%q", tt.ext, tt.expected, found)
+		}
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	for ext, src := range map[string]string{
+		".json": `{"a": }`,
+		".yml":  "a: [b",
+		".toml": "a = \"b",
+	} {
+		if _, err := Parse(src, ext); err == nil {
+			t.Errorf("%s: expected an error for %q", ext, src)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	path := []string{"paths", "/users", "get", "summary"}
+	for pattern, expected := range map[string]bool{
+		"paths.*.*.summary": true,
+		"**.summary":        true,
+		"**":                true,
+		"paths.**":          true,
+		"paths.*.summary":   false,
+		"summary":           false,
+		"**.description":    false,
+	} {
+		if Match(pattern, path) != expected {
+			t.Errorf("%s: expected %v", pattern, expected)
+		}
+	}
+}
+
+func TestLocate(t *testing.T) {
+	values, err := Parse(`{"a": "x\n\"TODO\" \u00e9"}`, ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct{ line, col, expected int }{
+		{1, 1, 8}, {2, 1, 11}, {2, 2, 13}, {2, 8, 20}, {2, 9, 21},
+	} {
+		if l, c := values[0].Locate(tt.line, tt.col); l != 1 || c != tt.expected {
+			t.Errorf("%d:%d: expected 1:%d, got %d:%d", tt.line, tt.col, tt.expected, l, c)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package data extracts the string values of structured data files (JSON,
+// YAML, and TOML) along with their locations.
+package data
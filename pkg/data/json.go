@@ -0,0 +1,93 @@
+package data
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type jsonParser struct {
+	*doc
+	i int
+}
+
+func parseJSON(d *doc) error {
+	if !json.Valid([]byte(d.src)) {
+		var v interface{}
+		return json.Unmarshal([]byte(d.src), &v)
+	}
+
+	p := jsonParser{doc: d}
+	p.value(nil)
+
+	return nil
+}
+
+// value reads the value at the current offset, which we know is valid.
+func (p *jsonParser) value(path []string) {
+	p.space()
+	switch p.src[p.i] {
+	case '{':
+		p.i++
+		for p.space(); p.src[p.i] != '}'; p.space() {
+			key := p.str().text.String()
+			p.space()
+			p.i++ // ':'
+			p.value(appendKey(path, key))
+			p.space()
+			if p.src[p.i] == ',' {
+				p.i++
+			}
+		}
+		p.i++
+	case '[':
+		p.i++
+		for n := 0; ; n++ {
+			if p.space(); p.src[p.i] == ']' {
+				break
+			}
+			p.value(appendKey(path, strconv.Itoa(n)))
+			p.space()
+			if p.src[p.i] == ',' {
+				p.i++
+			}
+		}
+		p.i++
+	case '"':
+		b := p.str()
+		p.values = append(p.values, b.value(path))
+	default:
+		for p.i < len(p.src) && !strings.ContainsRune(",]} \t\r\n", rune(p.src[p.i])) {
+			p.i++
+		}
+	}
+}
+
+// str reads the string at the current offset.
+func (p *jsonParser) str() *builder {
+	b := builder{d: p.doc}
+	for p.i++; p.src[p.i] != '"'; {
+		if p.src[p.i] == '\\' {
+			s, n := unescape(p.src, p.i)
+			if s == "\n" {
+				b.newline(p.i)
+			} else {
+				b.add(s, p.i)
+			}
+			p.i += n
+		} else {
+			_, n := utf8.DecodeRuneInString(p.src[p.i:])
+			b.add(p.src[p.i:p.i+n], p.i)
+			p.i += n
+		}
+	}
+	p.i++
+	return &b
+}
+
+func (p *jsonParser) space() {
+	for p.i < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[p.i])) {
+		p.i++
+	}
+}
@@ -0,0 +1,284 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tomlParser struct {
+	*doc
+	i      int
+	tables map[string]int // the number of entries in each array of tables
+}
+
+func parseTOML(d *doc) error {
+	p := tomlParser{doc: d, tables: make(map[string]int)}
+
+	var table []string
+	for p.space(true); p.i < len(p.src); p.space(true) {
+		var err error
+		if p.src[p.i] == '[' {
+			table, err = p.header()
+		} else {
+			err = p.pair(table)
+		}
+
+		if err != nil {
+			return err
+		} else if p.space(false); p.i < len(p.src) && p.src[p.i] != '\n' && p.src[p.i] != '\r' {
+			return p.errorf("expected a newline")
+		}
+	}
+
+	return nil
+}
+
+// header reads a table header -- e.g., `[a.b]` or `[[a.b]]` -- and returns
+// its path.
+func (p *tomlParser) header() ([]string, error) {
+	array := strings.HasPrefix(p.src[p.i:], "[[")
+	if array {
+		p.i += 2
+	} else {
+		p.i++
+	}
+
+	key, err := p.key()
+	if err != nil {
+		return nil, err
+	}
+
+	closing := "]"
+	if array {
+		closing = "]]"
+	}
+	if p.space(false); !strings.HasPrefix(p.src[p.i:], closing) {
+		return nil, p.errorf("expected '%s'", closing)
+	}
+	p.i += len(closing)
+
+	// Tables within an array of tables belong to its last entry.
+	path := []string{}
+	for i, part := range key {
+		path = append(path, part)
+		name := strings.Join(path, "\x00")
+		if array && i == len(key)-1 {
+			path = append(path, strconv.Itoa(p.tables[name]))
+			p.tables[name]++
+		} else if n, found := p.tables[name]; found {
+			path = append(path, strconv.Itoa(n-1))
+		}
+	}
+
+	return path, nil
+}
+
+// pair reads a `key = value` pair.
+func (p *tomlParser) pair(table []string) error {
+	key, err := p.key()
+	if err != nil {
+		return err
+	}
+
+	if p.space(false); p.i >= len(p.src) || p.src[p.i] != '=' {
+		return p.errorf("expected '='")
+	}
+	p.i++
+
+	return p.value(append(table[:len(table):len(table)], key...))
+}
+
+// key reads a (possibly dotted) key.
+func (p *tomlParser) key() ([]string, error) {
+	parts := []string{}
+	for {
+		p.space(false)
+		if p.i >= len(p.src) {
+			return nil, p.errorf("expected a key")
+		}
+
+		switch c := p.src[p.i]; {
+		case c == '"' || c == '\'':
+			b, err := p.str(false)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, b.text.String())
+		default:
+			start := p.i
+			for p.i < len(p.src) && isBare(p.src[p.i]) {
+				p.i++
+			}
+			if p.i == start {
+				return nil, p.errorf("expected a key")
+			}
+			parts = append(parts, p.src[start:p.i])
+		}
+
+		if p.space(false); p.i >= len(p.src) || p.src[p.i] != '.' {
+			return parts, nil
+		}
+		p.i++
+	}
+}
+
+func (p *tomlParser) value(path []string) error {
+	p.space(false)
+	if p.i >= len(p.src) {
+		return p.errorf("expected a value")
+	}
+
+	switch p.src[p.i] {
+	case '"', '\'':
+		b, err := p.str(true)
+		if err != nil {
+			return err
+		}
+		p.values = append(p.values, b.value(path))
+	case '[':
+		p.i++
+		for n := 0; ; n++ {
+			if p.space(true); p.i < len(p.src) && p.src[p.i] == ']' {
+				break
+			} else if err := p.value(appendKey(path, strconv.Itoa(n))); err != nil {
+				return err
+			}
+			if p.space(true); p.i < len(p.src) && p.src[p.i] == ',' {
+				p.i++
+			} else if p.i >= len(p.src) || p.src[p.i] != ']' {
+				return p.errorf("expected ']'")
+			}
+		}
+		p.i++
+	case '{':
+		p.i++
+		for {
+			if p.space(true); p.i < len(p.src) && p.src[p.i] == '}' {
+				break
+			} else if err := p.pair(path); err != nil {
+				return err
+			}
+			if p.space(true); p.i < len(p.src) && p.src[p.i] == ',' {
+				p.i++
+			} else if p.i >= len(p.src) || p.src[p.i] != '}' {
+				return p.errorf("expected '}'")
+			}
+		}
+		p.i++
+	default:
+		// A number, boolean, or date.
+		start := p.i
+		for p.i < len(p.src) && !strings.ContainsRune(",]}#\r\n", rune(p.src[p.i])) {
+			p.i++
+		}
+		if strings.TrimSpace(p.src[start:p.i]) == "" {
+			return p.errorf("expected a value")
+		}
+	}
+
+	return nil
+}
+
+// str reads a basic ("...") or literal ('...') string, which may span
+// multiple lines if `multi` is true and it's delimited by three quotes.
+func (p *tomlParser) str(multi bool) (*builder, error) {
+	b := builder{d: p.doc}
+
+	q := p.src[p.i : p.i+1]
+	if multi && strings.HasPrefix(p.src[p.i:], q+q+q) {
+		q = q + q + q
+	}
+	start := p.i
+	p.i += len(q)
+
+	if len(q) == 3 {
+		// A newline immediately following the opening delimiter is trimmed.
+		if strings.HasPrefix(p.src[p.i:], "\r\n") {
+			p.i += 2
+		} else if strings.HasPrefix(p.src[p.i:], "\n") {
+			p.i++
+		}
+	}
+
+	for p.i < len(p.src) {
+		switch c := p.src[p.i]; {
+		case strings.HasPrefix(p.src[p.i:], q):
+			// Up to two quotes may precede a multi-line string's delimiter.
+			for len(q) == 3 && strings.HasPrefix(p.src[p.i+1:], q) {
+				b.add(q[:1], p.i)
+				p.i++
+			}
+			p.i += len(q)
+			return &b, nil
+		case c == '\n':
+			if len(q) == 1 {
+				return nil, p.errorf("unterminated string")
+			}
+			b.newline(p.i)
+			p.i++
+		case c == '\r' && strings.HasPrefix(p.src[p.i:], "\r\n"):
+			p.i++
+		case c == '\\' && q[0] == '"':
+			if len(q) == 3 && strings.TrimLeft(p.rest(p.i+1), " \t\r") == "" {
+				// A line-ending backslash, which trims the whitespace that
+				// follows it.
+				for p.i++; p.i < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[p.i])); {
+					p.i++
+				}
+				continue
+			}
+			s, n := unescape(p.src, p.i)
+			if s == "\n" {
+				b.newline(p.i)
+			} else {
+				b.add(s, p.i)
+			}
+			p.i += n
+		default:
+			_, n := utf8.DecodeRuneInString(p.src[p.i:])
+			b.add(p.src[p.i:p.i+n], p.i)
+			p.i += n
+		}
+	}
+
+	p.i = start
+	return nil, p.errorf("unterminated string")
+}
+
+// space moves past whitespace and comments (and newlines, if `newlines` is
+// true).
+func (p *tomlParser) space(newlines bool) {
+	for p.i < len(p.src) {
+		switch c := p.src[p.i]; {
+		case c == ' ' || c == '\t':
+			p.i++
+		case c == '#':
+			for p.i < len(p.src) && p.src[p.i] != '\n' {
+				p.i++
+			}
+		case (c == '\n' || c == '\r') && newlines:
+			p.i++
+		default:
+			return
+		}
+	}
+}
+
+// rest returns the rest of the line from offset `i`.
+func (p *tomlParser) rest(i int) string {
+	if end := strings.IndexByte(p.src[i:], '\n'); end >= 0 {
+		return p.src[i : i+end]
+	}
+	return p.src[i:]
+}
+
+func (p *tomlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.line(p.i), fmt.Sprintf(format, args...))
+}
+
+func isBare(c byte) bool {
+	return c == '_' || c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9')
+}
@@ -0,0 +1,335 @@
+package data
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jdkato/regexp"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	reYAMLKey = regexp.MustCompile(
+		`^("(?:[^"\\]|\\.)*"|'(?:[^']|'')*'|[^\s#'"?:,\[\]{}|>&*!%@` + "`" + `][^#]*?)\s*:(?:\s+|$)`)
+	reYAMLScalar = regexp.MustCompile(
+		`^(?:~|null|Null|NULL|true|True|TRUE|false|False|FALSE|yes|Yes|YES|no|No|NO|on|On|ON|off|Off|OFF|` +
+			`[-+]?(?:\d[\d_]*(?:\.[\d_]*)?|\.\d+)(?:[eE][-+]?\d+)?|0x[\da-fA-F_]+|0o?[0-7_]+|` +
+			`[-+]?\.(?:inf|Inf|INF)|\.(?:nan|NaN|NAN))$`)
+)
+
+// yamlLine is the location of a line (without its newline) in the source.
+type yamlLine struct {
+	start, off, end int // `off` is the offset of the line's content
+}
+
+func (l yamlLine) indent() int {
+	return l.off - l.start
+}
+
+type yamlParser struct {
+	*doc
+	lines []yamlLine
+	i     int
+}
+
+func parseYAML(d *doc) error {
+	dec := yaml.NewDecoder(strings.NewReader(d.src))
+	for {
+		var v interface{}
+		if err := dec.Decode(&v); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	p := yamlParser{doc: d}
+	for i, start := range d.lines {
+		end := len(d.src)
+		if i+1 < len(d.lines) {
+			end = d.lines[i+1] - 1
+		}
+		end = start + len(strings.TrimRight(d.src[start:end], "\r"))
+
+		off := start
+		for off < end && d.src[off] == ' ' {
+			off++
+		}
+		p.lines = append(p.lines, yamlLine{start, off, end})
+	}
+
+	for p.skip(); p.i < len(p.lines); p.skip() {
+		p.node(nil, -1)
+		if p.skip(); p.i < len(p.lines) && p.lines[p.i].indent() == 0 {
+			// Anything we couldn't parse.
+			p.i++
+		}
+	}
+
+	return nil
+}
+
+// node reads the value on the current line, provided that it's indented
+// past `parent`.
+func (p *yamlParser) node(path []string, parent int) {
+	if p.skip(); p.i >= len(p.lines) || p.lines[p.i].indent() <= parent {
+		return
+	}
+
+	l := p.lines[p.i]
+	switch t := p.text(l); {
+	case isItem(t):
+		p.sequence(path, l.indent())
+	case reYAMLKey.MatchString(t):
+		p.mapping(path, l.indent())
+	default:
+		p.value(path, l.off, parent, false)
+	}
+}
+
+func (p *yamlParser) mapping(path []string, indent int) {
+	for p.skip(); p.i < len(p.lines); p.skip() {
+		l := p.lines[p.i]
+		if l.indent() != indent {
+			return
+		}
+
+		t := p.text(l)
+		m := reYAMLKey.FindStringSubmatchIndex(t)
+		if m == nil || isItem(t) {
+			return
+		}
+
+		key := t[m[2]:m[3]]
+		if strings.HasPrefix(key, `"`) {
+			if s, err := strconv.Unquote(key); err == nil {
+				key = s
+			}
+		} else if strings.HasPrefix(key, "'") {
+			key = strings.ReplaceAll(key[1:len(key)-1], "''", "'")
+		}
+
+		p.value(appendKey(path, key), l.off+m[1], indent, true)
+	}
+}
+
+func (p *yamlParser) sequence(path []string, indent int) {
+	for n := 0; p.i < len(p.lines); n++ {
+		if p.skip(); p.i >= len(p.lines) {
+			return
+		}
+
+		l := p.lines[p.i]
+		if l.indent() != indent || !isItem(p.text(l)) {
+			return
+		}
+		item := appendKey(path, strconv.Itoa(n))
+
+		pos := p.space(l.off+1, l.end)
+		if t := p.src[pos:l.end]; isItem(t) || reYAMLKey.MatchString(t) {
+			// A compact collection (e.g., `- key: value`), which we treat as
+			// if it started on its own line.
+			p.lines[p.i].off = pos
+			p.node(item, indent)
+		} else {
+			p.value(item, pos, indent, false)
+		}
+	}
+}
+
+// value reads the value that starts at offset `pos` of the current line and
+// belongs to a node indented by `indent`.
+func (p *yamlParser) value(path []string, pos, indent int, key bool) {
+	l := p.lines[p.i]
+
+	pos = p.space(pos, l.end)
+	for pos < l.end && (p.src[pos] == '&' || p.src[pos] == '!') {
+		// Skip the node's anchor or tag.
+		for pos < l.end && p.src[pos] != ' ' {
+			pos++
+		}
+		pos = p.space(pos, l.end)
+	}
+
+	switch t := p.src[pos:l.end]; {
+	case t == "" || t[0] == '#':
+		p.i++
+		if p.skip(); p.i < len(p.lines) && key {
+			if next := p.lines[p.i]; next.indent() == indent && isItem(p.text(next)) {
+				// A sequence that isn't indented past its key.
+				p.sequence(path, indent)
+				return
+			}
+		}
+		p.node(path, indent)
+	case t[0] == '|' || t[0] == '>':
+		p.block(path, indent)
+	case t[0] == '"' || t[0] == '\'':
+		p.quoted(path, pos)
+	case t[0] == '[' || t[0] == '{' || t[0] == '*':
+		for p.i++; p.i < len(p.lines); p.i++ {
+			if next := p.lines[p.i]; next.off < next.end && next.indent() <= indent {
+				break
+			}
+		}
+	default:
+		p.plain(path, pos, indent)
+	}
+}
+
+// plain reads a plain (i.e., unquoted) scalar.
+func (p *yamlParser) plain(path []string, pos, indent int) {
+	b := builder{d: p.doc}
+
+	l := p.lines[p.i]
+	p.add(&b, pos, p.uncomment(pos, l.end))
+
+	multiline := false
+	for p.i++; p.i < len(p.lines); p.i++ {
+		next := p.lines[p.i]
+		if t := p.text(next); t == "" || t[0] == '#' || next.indent() <= indent {
+			break
+		}
+		b.newline(l.end)
+		p.add(&b, next.off, p.uncomment(next.off, next.end))
+		l, multiline = next, true
+	}
+
+	if v := b.value(path); multiline || !reYAMLScalar.MatchString(v.Text) {
+		p.values = append(p.values, v)
+	}
+}
+
+// quoted reads a single- or double-quoted scalar.
+func (p *yamlParser) quoted(path []string, pos int) {
+	b := builder{d: p.doc}
+
+	q := p.src[pos]
+	for i := pos + 1; p.i < len(p.lines); {
+		l := p.lines[p.i]
+		if i >= l.end {
+			// A line break, which is folded unless it's escaped.
+			if p.i++; p.i < len(p.lines) {
+				if !strings.HasSuffix(p.src[l.start:l.end], "\\") || q == '\'' {
+					b.newline(l.end)
+				}
+				i = p.lines[p.i].off
+			}
+			continue
+		}
+
+		switch c := p.src[i]; {
+		case c == q && q == '\'' && i+1 < l.end && p.src[i+1] == '\'':
+			b.add("'", i)
+			i += 2
+		case c == q:
+			p.i++
+			p.values = append(p.values, b.value(path))
+			return
+		case c == '\\' && q == '"':
+			if i+1 == l.end {
+				i++
+				continue
+			}
+			s, n := unescape(p.src, i)
+			if s == "\n" {
+				b.newline(i)
+			} else {
+				b.add(s, i)
+			}
+			i += n
+		default:
+			_, n := utf8.DecodeRuneInString(p.src[i:])
+			b.add(p.src[i:i+n], i)
+			i += n
+		}
+	}
+}
+
+// block reads a literal (`|`) or folded (`>`) block scalar.
+func (p *yamlParser) block(path []string, indent int) {
+	b := builder{d: p.doc}
+
+	end, content := p.i+1, -1
+	for i := p.i + 1; i < len(p.lines); i++ {
+		l := p.lines[i]
+		if l.off == l.end {
+			continue
+		} else if l.indent() <= indent {
+			break
+		} else if content < 0 {
+			content = l.indent()
+		}
+		end = i + 1
+	}
+
+	for i := p.i + 1; i < end; i++ {
+		l := p.lines[i]
+		if i > p.i+1 {
+			b.newline(p.lines[i-1].end)
+		}
+		if l.start+content < l.end {
+			p.add(&b, l.start+content, l.end)
+		}
+	}
+
+	if content >= 0 {
+		p.values = append(p.values, b.value(path))
+	}
+	p.i = end
+}
+
+// skip moves past blank lines, comments, directives, and document markers.
+func (p *yamlParser) skip() {
+	for ; p.i < len(p.lines); p.i++ {
+		l := p.lines[p.i]
+		t := p.text(l)
+		if t != "" && t[0] != '#' && !(l.indent() == 0 && isMarker(t)) {
+			return
+		}
+	}
+}
+
+// add appends the source between `start` and `end` to `b`.
+func (p *yamlParser) add(b *builder, start, end int) {
+	for i := start; i < end; {
+		_, n := utf8.DecodeRuneInString(p.src[i:])
+		b.add(p.src[i:i+n], i)
+		i += n
+	}
+}
+
+// uncomment returns the end of the content between `start` and `end`,
+// excluding any trailing comment and whitespace.
+func (p *yamlParser) uncomment(start, end int) int {
+	if i := strings.Index(p.src[start:end], " #"); i >= 0 {
+		end = start + i
+	}
+	return start + len(strings.TrimRight(p.src[start:end], " \t"))
+}
+
+func (p *yamlParser) space(i, end int) int {
+	for i < end && (p.src[i] == ' ' || p.src[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+func (p *yamlParser) text(l yamlLine) string {
+	return p.src[l.off:l.end]
+}
+
+func isItem(t string) bool {
+	return t == "-" || strings.HasPrefix(t, "- ") || strings.HasPrefix(t, "-\t")
+}
+
+func isMarker(t string) bool {
+	for _, m := range []string{"---", "...", "%"} {
+		if t == m || strings.HasPrefix(t, m+" ") || (m == "%" && strings.HasPrefix(t, m)) {
+			return true
+		}
+	}
+	return false
+}
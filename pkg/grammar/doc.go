@@ -0,0 +1,3 @@
+// Package grammar implements an offline, pure-Go subset of LanguageTool's
+// XML rule format (`grammar.xml`).
+package grammar
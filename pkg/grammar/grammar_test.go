@@ -0,0 +1,105 @@
+package grammar
+
+import (
+	"strings"
+	"testing"
+)
+
+var testRules = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE rules [
+  <!ENTITY pronoun "he|she|it">
+]>
+<rules lang="en">
+  <category id="GRAMMAR" name="Grammar">
+    <rule id="THEIR_IS" name="their is">
+      <pattern>
+        <marker>
+          <token regexp="yes">their|there</token>
+        </marker>
+        <token>is</token>
+      </pattern>
+      <message>Did you mean <suggestion>there</suggestion>?</message>
+    </rule>
+    <rulegroup id="PRONOUN_DONT" name="he don't">
+      <rule>
+        <pattern>
+          <token regexp="yes">&pronoun;</token>
+          <token skip="1">do</token>
+          <token>not</token>
+        </pattern>
+        <message>Use <suggestion>\1 does not</suggestion>.</message>
+      </rule>
+    </rulegroup>
+    <rule id="DT_DT" name="a the">
+      <pattern>
+        <token postag="DT"><exception>all</exception></token>
+        <token postag="DT"/>
+      </pattern>
+      <message>Remove one of the determiners.</message>
+    </rule>
+    <rule id="OFF" name="off" default="off">
+      <pattern>
+        <token>is</token>
+      </pattern>
+      <message>Off.</message>
+    </rule>
+  </category>
+</rules>`
+
+func TestGrammar(t *testing.T) {
+	rules, err := Load(strings.NewReader(testRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rules) != 3 {
+		t.Fatalf("expected 3 rules, got %d", len(rules))
+	} else if !NeedsTags(rules) {
+		t.Error("expected rules to need tags")
+	}
+
+	text := "Their is a cat, and she do really not care."
+	tokens := Tokenize(text)
+
+	m := rules[0].Find(text, tokens)
+	if len(m) != 1 || text[m[0].Start:m[0].End] != "Their" {
+		t.Fatalf("unexpected matches: %v", m)
+	} else if m[0].Message != "Did you mean 'there'?" {
+		t.Errorf("unexpected message: %s", m[0].Message)
+	}
+
+	m = rules[1].Find(text, tokens)
+	if len(m) != 1 || text[m[0].Start:m[0].End] != "she do really not" {
+		t.Fatalf("unexpected matches: %v", m)
+	} else if m[0].Suggestions[0] != "she does not" {
+		t.Errorf("unexpected suggestions: %v", m[0].Suggestions)
+	} else if rules[1].ID != "PRONOUN_DONT[1]" {
+		t.Errorf("unexpected ID: %s", rules[1].ID)
+	}
+
+	tokens = Tokenize("Take all the cake, not a the cake.")
+	for i := range tokens {
+		switch tokens[i].Text {
+		case "all", "the", "a":
+			tokens[i].Tag = "DT"
+		}
+	}
+
+	m = rules[2].Find("Take all the cake, not a the cake.", tokens)
+	if len(m) != 1 || m[0].Start != 23 {
+		t.Errorf("unexpected matches: %v", m)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize("It's well-known, isn't it?")
+
+	words := []string{}
+	for _, tok := range tokens {
+		words = append(words, tok.Text)
+	}
+
+	if strings.Join(words, "|") != "It's|well-known|,|isn't|it|?" {
+		t.Errorf("unexpected tokens: %v", words)
+	}
+}
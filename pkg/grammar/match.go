@@ -0,0 +1,253 @@
+package grammar
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A Token is a word or punctuation mark, along with its location in the
+// source text.
+type Token struct {
+	Text  string
+	Tag   string // part-of-speech tag (e.g., "NN"), if known
+	Start int    // byte offset
+	End   int    // byte offset
+}
+
+// A Rule is a single LanguageTool-style pattern rule.
+type Rule struct {
+	ID       string
+	Name     string
+	Category string
+	Short    string
+
+	message     string
+	suggestions []string
+	elements    []element
+}
+
+// A Match is an occurrence of a Rule's pattern.
+type Match struct {
+	Rule        *Rule
+	Start       int    // byte offset of the first marked token
+	End         int    // byte offset of the end of the last marked token
+	Message     string // the rule's message, with back-references expanded
+	Suggestions []string
+}
+
+// element is a single `<token>` of a pattern.
+type element struct {
+	text      *regexp.Regexp // nil matches any token
+	postag    *regexp.Regexp // nil matches any tag
+	negate    bool
+	negatePOS bool
+	skip      int // how many tokens may follow this one (-1 for any)
+	min       int
+	max       int // -1 for any
+	marked    bool
+
+	exceptions []element
+}
+
+var reBackRef = regexp.MustCompile(`\\(\d+)`)
+
+// Tokenize splits `text` into words and punctuation.
+//
+// Words may contain internal apostrophes and hyphens (e.g., "don't" and
+// "well-known").
+func Tokenize(text string) []Token {
+	tokens := []Token{}
+
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if start >= 0 && !isWord && (r == '\'' || r == '’' || r == '-') {
+			next, _ := utf8.DecodeRuneInString(text[i+utf8.RuneLen(r):])
+			isWord = unicode.IsLetter(next) || unicode.IsDigit(next)
+		}
+
+		if isWord {
+			if start < 0 {
+				start = i
+			}
+			continue
+		} else if start >= 0 {
+			tokens = append(tokens, Token{Text: text[start:i], Start: start, End: i})
+			start = -1
+		}
+
+		if !unicode.IsSpace(r) {
+			end := i + utf8.RuneLen(r)
+			tokens = append(tokens, Token{Text: text[i:end], Start: i, End: end})
+		}
+	}
+
+	if start >= 0 {
+		tokens = append(tokens, Token{Text: text[start:], Start: start, End: len(text)})
+	}
+
+	return tokens
+}
+
+// NeedsTags reports whether any of the rules use part-of-speech tags.
+func NeedsTags(rules []*Rule) bool {
+	for _, r := range rules {
+		for _, e := range r.elements {
+			if e.usesTags() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e element) usesTags() bool {
+	if e.postag != nil {
+		return true
+	}
+	for _, ex := range e.exceptions {
+		if ex.usesTags() {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns all non-overlapping matches of `r` in `tokens`, which were
+// produced from `text`.
+func (r *Rule) Find(text string, tokens []Token) []Match {
+	matches := []Match{}
+
+	for i := 0; i < len(tokens); i++ {
+		spans := make([][2]int, len(r.elements))
+		if !r.matchAt(tokens, 0, i, spans) {
+			continue
+		}
+
+		end := i
+		for _, sp := range spans {
+			if sp[1] > sp[0] && sp[1] > end {
+				end = sp[1]
+			}
+		}
+
+		if end > i {
+			matches = append(matches, r.makeMatch(text, tokens, spans))
+			// Resume after the last token of this match.
+			i = end - 1
+		}
+	}
+
+	return matches
+}
+
+// matchAt tries to match the elements from `ei` onward starting at token
+// `ti`, recording the tokens matched by each element in `spans`.
+func (r *Rule) matchAt(tokens []Token, ei, ti int, spans [][2]int) bool {
+	if ei == len(r.elements) {
+		return true
+	}
+	e := r.elements[ei]
+
+	// Find the longest run of tokens that this element could match.
+	longest := 0
+	for ti+longest < len(tokens) && (e.max < 0 || longest < e.max) {
+		if !e.matches(tokens[ti+longest]) {
+			break
+		}
+		longest++
+	}
+
+	for count := longest; count >= e.min; count-- {
+		spans[ei] = [2]int{ti, ti + count}
+
+		next := ti + count
+		for gap := 0; ; gap++ {
+			if ei+1 < len(r.elements) && next+gap > len(tokens) {
+				break
+			} else if r.matchAt(tokens, ei+1, next+gap, spans) {
+				return true
+			} else if ei+1 == len(r.elements) || (e.skip >= 0 && gap >= e.skip) {
+				break
+			}
+		}
+	}
+
+	return false
+}
+
+func (e element) matches(tok Token) bool {
+	textOK := e.text == nil || e.text.MatchString(tok.Text)
+	if e.negate {
+		textOK = !textOK
+	}
+
+	posOK := e.postag == nil || e.postag.MatchString(tok.Tag)
+	if e.negatePOS {
+		posOK = !posOK
+	}
+
+	if !textOK || !posOK {
+		return false
+	}
+
+	for _, ex := range e.exceptions {
+		if ex.matches(tok) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Rule) makeMatch(text string, tokens []Token, spans [][2]int) Match {
+	first, last := -1, -1
+	for i, sp := range spans {
+		if r.elements[i].marked && sp[1] > sp[0] {
+			if first < 0 {
+				first = sp[0]
+			}
+			last = sp[1] - 1
+		}
+	}
+
+	if first < 0 {
+		// There's no (non-empty) `<marker>`, so we use the entire match.
+		for _, sp := range spans {
+			if sp[1] > sp[0] {
+				if first < 0 {
+					first = sp[0]
+				}
+				last = sp[1] - 1
+			}
+		}
+	}
+
+	expand := func(s string) string {
+		return reBackRef.ReplaceAllStringFunc(s, func(ref string) string {
+			n, _ := strconv.Atoi(ref[1:])
+			if n < 1 || n > len(spans) || spans[n-1][1] <= spans[n-1][0] {
+				return ""
+			}
+			sp := spans[n-1]
+			return text[tokens[sp[0]].Start:tokens[sp[1]-1].End]
+		})
+	}
+
+	m := Match{Rule: r, Message: expand(r.message)}
+	if first >= 0 {
+		m.Start, m.End = tokens[first].Start, tokens[last].End
+	}
+
+	for _, s := range r.suggestions {
+		s = strings.TrimSpace(expand(s))
+		if s != "" {
+			m.Suggestions = append(m.Suggestions, s)
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,300 @@
+package grammar
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// node is a generic XML element.
+type node struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Inner    string     `xml:",innerxml"`
+	Children []node     `xml:",any"`
+}
+
+func (n node) attr(name string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func (n node) text() string {
+	var b strings.Builder
+
+	d := xml.NewDecoder(strings.NewReader("<x>" + n.Inner + "</x>"))
+	d.Strict = false
+	for {
+		t, err := d.Token()
+		if err != nil {
+			break
+		}
+		if c, ok := t.(xml.CharData); ok {
+			b.Write(c)
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+var (
+	reException  = regexp.MustCompile(`(?s)<exception.*?(?:/>|</exception>)`)
+	reEntity     = regexp.MustCompile(`<!ENTITY\s+(\S+)\s+"([^"]*)"\s*>`)
+	reSuggestion = regexp.MustCompile(`(?s)<suggestion[^>]*>(.*?)</suggestion>`)
+	reMatchRef   = regexp.MustCompile(`<match\s+no="(\d+)"[^>]*/>`)
+	reTag        = regexp.MustCompile(`<[^>]+>`)
+	reWhitespace = regexp.MustCompile(`\s+`)
+)
+
+// LoadFile reads the rules defined in the LanguageTool-style XML file at
+// `path`.
+func LoadFile(path string) ([]*Rule, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Load(f)
+}
+
+// Load reads LanguageTool-style XML rules from `r`.
+//
+// The supported subset includes token strings and regular expressions,
+// part-of-speech tags, `skip`, `min`/`max`, negation (`negate`,
+// `negate_pos`, and `<exception>`), `<marker>`, and suggestions (including
+// `\1` and `<match no="1"/>` back-references). Rules that are turned off by
+// default (`default="off"`) are skipped, as are elements outside of the
+// supported subset (e.g., `<antipattern>`).
+func Load(r io.Reader) ([]*Rule, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: LanguageTool's rule files make heavy use of entities (e.g.,
+	// `&months;`) defined in their DOCTYPE. We expand them up front since
+	// we re-read the inner XML of some elements (see `node.text`).
+	for _, m := range reEntity.FindAllSubmatch(b, -1) {
+		b = bytes.Replace(b, []byte("&"+string(m[1])+";"), m[2], -1)
+	}
+
+	root := node{}
+	if err = xml.Unmarshal(b, &root); err != nil {
+		return nil, err
+	}
+
+	rules := []*Rule{}
+	for _, cat := range root.Children {
+		if cat.XMLName.Local != "category" || isOff(cat) {
+			continue
+		}
+
+		for _, child := range cat.Children {
+			group := []node{child}
+			if child.XMLName.Local == "rulegroup" {
+				if isOff(child) {
+					continue
+				}
+				group = child.Children
+			} else if child.XMLName.Local != "rule" {
+				continue
+			}
+
+			for i, sub := range group {
+				if sub.XMLName.Local != "rule" {
+					continue
+				}
+
+				id, name := sub.attr("id"), sub.attr("name")
+				if id == "" {
+					// A rule within a group is identified by its position.
+					id = fmt.Sprintf("%s[%d]", child.attr("id"), i+1)
+				}
+				if name == "" {
+					name = child.attr("name")
+				}
+
+				rule, err := newRule(sub, id, name)
+				if err != nil {
+					return nil, err
+				} else if rule != nil {
+					rule.Category = cat.attr("id")
+					rules = append(rules, rule)
+				}
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+func isOff(n node) bool {
+	value := n.attr("default")
+	return value == "off" || value == "temp_off"
+}
+
+func newRule(n node, id, name string) (*Rule, error) {
+	if isOff(n) {
+		return nil, nil
+	}
+
+	rule := &Rule{ID: id, Name: name}
+	for _, child := range n.Children {
+		switch child.XMLName.Local {
+		case "pattern":
+			if err := rule.addPattern(child); err != nil {
+				return nil, fmt.Errorf("rule '%s': %s", id, err)
+			}
+		case "message":
+			rule.message, rule.suggestions = parseMessage(child.Inner)
+		case "short":
+			rule.Short = child.text()
+		case "suggestion":
+			_, suggestions := parseMessage(
+				"<suggestion>" + child.Inner + "</suggestion>")
+			rule.suggestions = append(rule.suggestions, suggestions...)
+		}
+	}
+
+	if len(rule.elements) == 0 {
+		// Unsupported (e.g., a `<regexp>`-based rule).
+		return nil, nil
+	}
+
+	return rule, nil
+}
+
+func (r *Rule) addPattern(n node) error {
+	caseSensitive := n.attr("case_sensitive") == "yes"
+	for _, child := range n.Children {
+		switch child.XMLName.Local {
+		case "token":
+			e, err := newElement(child, caseSensitive)
+			if err != nil {
+				return err
+			}
+			r.elements = append(r.elements, e)
+		case "marker":
+			for _, tok := range child.Children {
+				if tok.XMLName.Local != "token" {
+					continue
+				}
+				e, err := newElement(tok, caseSensitive)
+				if err != nil {
+					return err
+				}
+				e.marked = true
+				r.elements = append(r.elements, e)
+			}
+		}
+	}
+	return nil
+}
+
+func newElement(n node, caseSensitive bool) (element, error) {
+	var err error
+
+	e := element{min: 1, max: 1}
+	if n.attr("case_sensitive") == "yes" {
+		caseSensitive = true
+	}
+
+	// `<exception>` elements are nested within the token's text, so we
+	// remove them before reading it.
+	text := n
+	text.Inner = reException.ReplaceAllString(n.Inner, "")
+
+	e.text, err = compileToken(
+		text.text(), n.attr("regexp") == "yes", !caseSensitive)
+	if err != nil {
+		return e, err
+	}
+
+	e.postag, err = compileToken(
+		n.attr("postag"), n.attr("postag_regexp") == "yes", false)
+	if err != nil {
+		return e, err
+	}
+
+	e.negate = n.attr("negate") == "yes"
+	e.negatePOS = n.attr("negate_pos") == "yes"
+
+	if skip := n.attr("skip"); skip != "" {
+		if e.skip, err = strconv.Atoi(skip); err != nil {
+			return e, err
+		}
+	}
+
+	if min := n.attr("min"); min != "" {
+		if e.min, err = strconv.Atoi(min); err != nil {
+			return e, err
+		}
+	}
+
+	if max := n.attr("max"); max != "" {
+		if e.max, err = strconv.Atoi(max); err != nil {
+			return e, err
+		}
+	} else if e.min > 1 {
+		e.max = e.min
+	}
+
+	for _, child := range n.Children {
+		if child.XMLName.Local != "exception" {
+			continue
+		}
+		ex, err := newElement(child, caseSensitive)
+		if err != nil {
+			return e, err
+		}
+		e.exceptions = append(e.exceptions, ex)
+	}
+
+	return e, nil
+}
+
+func compileToken(s string, isRegex, ignoreCase bool) (*regexp.Regexp, error) {
+	if s == "" {
+		return nil, nil
+	} else if !isRegex {
+		s = regexp.QuoteMeta(s)
+	}
+
+	s = `^(?:` + s + `)$`
+	if ignoreCase {
+		s = `(?i)` + s
+	}
+
+	return regexp.Compile(s)
+}
+
+// parseMessage converts a `<message>`'s inner XML into plain text (with its
+// suggestions quoted) and a list of suggestion templates.
+func parseMessage(inner string) (string, []string) {
+	suggestions := []string{}
+
+	inner = reMatchRef.ReplaceAllString(inner, `\$1`)
+	msg := reSuggestion.ReplaceAllStringFunc(inner, func(s string) string {
+		sub := reSuggestion.FindStringSubmatch(s)[1]
+		sub = html.UnescapeString(reTag.ReplaceAllString(sub, ""))
+		suggestions = append(suggestions, sub)
+		return "'" + sub + "'"
+	})
+
+	msg = html.UnescapeString(reTag.ReplaceAllString(msg, ""))
+	msg = reWhitespace.ReplaceAllString(strings.TrimSpace(msg), " ")
+
+	return msg, suggestions
+}
@@ -0,0 +1,3 @@
+// Package inflect implements rule-based inflection of English base forms
+// (plurals, verb tenses, and possessives).
+package inflect
@@ -0,0 +1,258 @@
+package inflect
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Forms are the inflections we know how to produce, in the order they're
+// returned by `Inflections`.
+var Forms = []string{"plural", "possessive", "third", "past", "gerund"}
+
+var irregularVerbs = map[string][]string{
+	// base: {third-person singular, past, gerund}
+	"be":     {"is", "was", "being"},
+	"begin":  {"begins", "began", "beginning"},
+	"bring":  {"brings", "brought", "bringing"},
+	"build":  {"builds", "built", "building"},
+	"buy":    {"buys", "bought", "buying"},
+	"catch":  {"catches", "caught", "catching"},
+	"choose": {"chooses", "chose", "choosing"},
+	"come":   {"comes", "came", "coming"},
+	"do":     {"does", "did", "doing"},
+	"draw":   {"draws", "drew", "drawing"},
+	"drive":  {"drives", "drove", "driving"},
+	"find":   {"finds", "found", "finding"},
+	"get":    {"gets", "got", "getting"},
+	"give":   {"gives", "gave", "giving"},
+	"go":     {"goes", "went", "going"},
+	"have":   {"has", "had", "having"},
+	"hold":   {"holds", "held", "holding"},
+	"keep":   {"keeps", "kept", "keeping"},
+	"know":   {"knows", "knew", "knowing"},
+	"lead":   {"leads", "led", "leading"},
+	"leave":  {"leaves", "left", "leaving"},
+	"let":    {"lets", "let", "letting"},
+	"make":   {"makes", "made", "making"},
+	"mean":   {"means", "meant", "meaning"},
+	"put":    {"puts", "put", "putting"},
+	"read":   {"reads", "read", "reading"},
+	"run":    {"runs", "ran", "running"},
+	"say":    {"says", "said", "saying"},
+	"see":    {"sees", "saw", "seeing"},
+	"seek":   {"seeks", "sought", "seeking"},
+	"sell":   {"sells", "sold", "selling"},
+	"send":   {"sends", "sent", "sending"},
+	"set":    {"sets", "set", "setting"},
+	"show":   {"shows", "showed", "showing"},
+	"take":   {"takes", "took", "taking"},
+	"teach":  {"teaches", "taught", "teaching"},
+	"tell":   {"tells", "told", "telling"},
+	"think":  {"thinks", "thought", "thinking"},
+	"write":  {"writes", "wrote", "writing"},
+}
+
+var irregularNouns = map[string]string{
+	"child":      "children",
+	"criterion":  "criteria",
+	"datum":      "data",
+	"foot":       "feet",
+	"index":      "indices",
+	"man":        "men",
+	"matrix":     "matrices",
+	"medium":     "media",
+	"mouse":      "mice",
+	"person":     "people",
+	"phenomenon": "phenomena",
+	"tooth":      "teeth",
+	"vertex":     "vertices",
+	"woman":      "women",
+}
+
+// Inflections returns the inflected forms of `term`, keyed by their name in
+// `Forms`.
+//
+// Since we don't know a term's part of speech, both noun and verb forms are
+// included. Multi-word terms are inflected on their last word as nouns
+// (e.g., "email address" -> "email addresses") and on their first word as
+// verbs (e.g., "log in" -> "logged in"). Hyphenated compounds (e.g.,
+// "e-mail") are only inflected as nouns.
+func Inflections(term string) map[string]string {
+	forms := make(map[string]string)
+
+	last := strings.LastIndexAny(term, " -") + 1
+	first := strings.Index(term, " ")
+	if first < 0 {
+		first = len(term)
+	}
+
+	noun, verb := term[last:], term[:first]
+	if noun == "" || !isWord(noun) {
+		return forms
+	}
+
+	for name, inflect := range map[string]func(string) string{
+		"plural":     plural,
+		"possessive": possessive,
+	} {
+		forms[name] = term[:last] + matchCase(noun, inflect(strings.ToLower(noun)))
+	}
+
+	if !isWord(verb) {
+		return forms
+	}
+
+	for name, inflect := range map[string]func(string) string{
+		"third":  third,
+		"past":   past,
+		"gerund": gerund,
+	} {
+		forms[name] = matchCase(verb, inflect(strings.ToLower(verb))) + term[first:]
+	}
+
+	return forms
+}
+
+// Expand returns `term` followed by all of its distinct inflections.
+func Expand(term string) []string {
+	expanded := []string{term}
+
+	forms := Inflections(term)
+	for _, name := range Forms {
+		if form, ok := forms[name]; ok && !contains(expanded, form) {
+			expanded = append(expanded, form)
+		}
+	}
+
+	return expanded
+}
+
+func plural(word string) string {
+	if p, ok := irregularNouns[word]; ok {
+		return p
+	}
+	return sibilant(word)
+}
+
+func third(word string) string {
+	if v, ok := irregularVerbs[word]; ok {
+		return v[0]
+	}
+	return sibilant(word)
+}
+
+func sibilant(word string) string {
+	switch {
+	case hasAnySuffix(word, "s", "x", "z", "ch", "sh"):
+		return word + "es"
+	case endsWithConsonantY(word):
+		return word[:len(word)-1] + "ies"
+	}
+	return word + "s"
+}
+
+func possessive(word string) string {
+	if strings.HasSuffix(word, "s") {
+		return word + "'"
+	}
+	return word + "'s"
+}
+
+func past(word string) string {
+	switch v, ok := irregularVerbs[word]; {
+	case ok:
+		return v[1]
+	case strings.HasSuffix(word, "e"):
+		return word + "d"
+	case endsWithConsonantY(word):
+		return word[:len(word)-1] + "ied"
+	case doublesFinal(word):
+		return word + word[len(word)-1:] + "ed"
+	}
+	return word + "ed"
+}
+
+func gerund(word string) string {
+	switch v, ok := irregularVerbs[word]; {
+	case ok:
+		return v[2]
+	case strings.HasSuffix(word, "ie"):
+		return word[:len(word)-2] + "ying"
+	case strings.HasSuffix(word, "e") && !hasAnySuffix(word, "ee", "ye", "oe"):
+		return word[:len(word)-1] + "ing"
+	case doublesFinal(word):
+		return word + word[len(word)-1:] + "ing"
+	}
+	return word + "ing"
+}
+
+// doublesFinal determines if a word's final consonant should be doubled
+// before adding a suffix -- e.g., "stop" -> "stopped".
+//
+// We only apply this to single-syllable words of the form
+// consonant-vowel-consonant since we can't know where the stress falls in
+// longer words.
+func doublesFinal(word string) bool {
+	n := len(word)
+	if n < 3 || strings.ContainsAny(word[n-1:], "wxy") {
+		return false
+	}
+
+	groups := 0
+	for i := range word {
+		if isVowel(word[i]) && (i == 0 || !isVowel(word[i-1])) {
+			groups++
+		}
+	}
+
+	return groups == 1 && !isVowel(word[n-1]) && isVowel(word[n-2]) && !isVowel(word[n-3])
+}
+
+func endsWithConsonantY(word string) bool {
+	n := len(word)
+	return n > 1 && word[n-1] == 'y' && !isVowel(word[n-2])
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+func hasAnySuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWord(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchCase applies the casing of `original` to `inflected`.
+func matchCase(original, inflected string) string {
+	if strings.HasPrefix(inflected, strings.ToLower(original)) {
+		// A regular inflection: keep `original` as-is and add the suffix.
+		return original + inflected[len(original):]
+	} else if original == strings.ToUpper(original) && len(original) > 1 {
+		return strings.ToUpper(inflected)
+	} else if original != strings.ToLower(original) {
+		return strings.ToUpper(inflected[:1]) + inflected[1:]
+	}
+	return inflected
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package inflect
+
+import "testing"
+
+func TestInflections(t *testing.T) {
+	cases := map[string]map[string]string{
+		"utilize": {"past": "utilized", "gerund": "utilizing", "third": "utilizes"},
+		"use":     {"past": "used", "gerund": "using", "plural": "uses"},
+		"stop":    {"past": "stopped", "gerund": "stopping"},
+		"apply":   {"past": "applied", "plural": "applies"},
+		"write":   {"past": "wrote", "gerund": "writing", "third": "writes"},
+		"box":     {"plural": "boxes", "possessive": "box's"},
+		"index":   {"plural": "indices"},
+		"GitHub":  {"possessive": "GitHub's", "plural": "GitHubs"},
+		"API":     {"plural": "APIs"},
+		"log in":  {"past": "logged in", "gerund": "logging in"},
+		"e-mail":  {"plural": "e-mails", "past": "", "gerund": ""},
+		"email address": {
+			"plural":     "email addresses",
+			"possessive": "email address'"},
+	}
+
+	for term, expected := range cases {
+		forms := Inflections(term)
+		for name, form := range expected {
+			if forms[name] != form {
+				t.Errorf("%s(%q): expected = %q, got = %q", name, term, form, forms[name])
+			}
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	forms := Expand("use")
+	if len(forms) != 5 || forms[0] != "use" {
+		t.Errorf("unexpected forms: %v", forms)
+	}
+}
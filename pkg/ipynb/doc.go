@@ -0,0 +1,2 @@
+// Package ipynb parses Jupyter notebooks (`.ipynb` files).
+package ipynb
@@ -0,0 +1,317 @@
+package ipynb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// extensions maps kernel languages to their file extensions, for notebooks
+// that don't specify one.
+var extensions = map[string]string{
+	"bash":       ".sh",
+	"c":          ".c",
+	"c#":         ".cs",
+	"c++":        ".cpp",
+	"go":         ".go",
+	"haskell":    ".hs",
+	"java":       ".java",
+	"javascript": ".js",
+	"julia":      ".jl",
+	"lua":        ".lua",
+	"php":        ".php",
+	"python":     ".py",
+	"r":          ".r",
+	"ruby":       ".rb",
+	"rust":       ".rs",
+	"scala":      ".scala",
+	"typescript": ".ts",
+}
+
+// A Loc is a location in a notebook's JSON.
+type Loc struct {
+	Line   int // the (1-based) line
+	Column int // the number of characters that precede the location
+
+	// Offsets maps each character of a cell's (decoded) line to its column
+	// in the JSON, which may differ from `Column` plus its position in the
+	// line because of escape sequences -- e.g., `\"` or `\u00e9`. Each
+	// offset is the number of JSON characters that precede the character.
+	Offsets []int
+}
+
+// Col returns the (1-based) column in the notebook's JSON of the 1-based
+// column `n` of the decoded line that starts at `l`.
+func (l Loc) Col(n int) int {
+	if n < 1 || len(l.Offsets) == 0 {
+		return n + l.Column
+	} else if n > len(l.Offsets) {
+		return n - len(l.Offsets) + l.Offsets[len(l.Offsets)-1] + 1
+	}
+	return l.Offsets[n-1] + 1
+}
+
+// A Cell is a notebook cell.
+type Cell struct {
+	Type   string // 'markdown', 'code', or 'raw'
+	Source string // the cell's content
+	Lines  []Loc  // the location of each line of Source in the notebook
+}
+
+// A Notebook is a parsed Jupyter notebook.
+type Notebook struct {
+	Cells     []Cell
+	Language  string // the kernel's language (e.g., "python")
+	Extension string // the kernel's file extension (e.g., ".py")
+}
+
+// Ext returns the file extension of the notebook's code cells, or an empty
+// string if it's unknown.
+func (nb *Notebook) Ext() string {
+	if nb.Extension != "" {
+		return nb.Extension
+	}
+	return extensions[strings.ToLower(nb.Language)]
+}
+
+type decoder struct {
+	*json.Decoder
+	src   []byte
+	lines []int // the offset of each line in src
+}
+
+// Parse parses the (nbformat 4) notebook `src`.
+func Parse(src []byte) (*Notebook, error) {
+	d := decoder{Decoder: json.NewDecoder(bytes.NewReader(src)), src: src}
+
+	d.lines = []int{0}
+	for i, b := range src {
+		if b == '\n' {
+			d.lines = append(d.lines, i+1)
+		}
+	}
+
+	nb := Notebook{}
+	err := d.object(func(key string) error {
+		switch key {
+		case "cells":
+			return d.array(func() error {
+				c, err := d.cell()
+				if err == nil {
+					nb.Cells = append(nb.Cells, c)
+				}
+				return err
+			})
+		case "metadata":
+			return d.object(func(key string) error {
+				switch key {
+				case "kernelspec":
+					return d.object(func(key string) error {
+						if key == "language" {
+							return d.str(&nb.Language)
+						}
+						return d.skip()
+					})
+				case "language_info":
+					return d.object(func(key string) error {
+						switch key {
+						case "name":
+							if nb.Language != "" {
+								return d.skip()
+							}
+							return d.str(&nb.Language)
+						case "file_extension":
+							return d.str(&nb.Extension)
+						}
+						return d.skip()
+					})
+				}
+				return d.skip()
+			})
+		}
+		return d.skip()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid notebook: %w", err)
+	}
+	return &nb, nil
+}
+
+func (d *decoder) cell() (Cell, error) {
+	c := Cell{}
+	err := d.object(func(key string) error {
+		switch key {
+		case "cell_type":
+			return d.str(&c.Type)
+		case "source":
+			return d.source(&c)
+		}
+		return d.skip()
+	})
+	return c, err
+}
+
+// source reads a cell's source, which is either a string or a list of
+// strings, and records the location of each of its lines.
+func (d *decoder) source(c *Cell) error {
+	var b strings.Builder
+
+	starts := true
+	read := func() error {
+		q := d.peek()
+		if q < 0 || d.src[q] != '"' {
+			return fmt.Errorf("expected a string at offset %d", d.InputOffset())
+		}
+
+		var s string
+		if err := d.str(&s); err != nil {
+			return err
+		}
+		b.WriteString(s)
+
+		// Find where each line -- and each of its characters -- starts in
+		// the (escaped) JSON string.
+		col, track := 0, false
+		for i := q + 1; i < len(d.src) && d.src[i] != '"'; {
+			if starts {
+				c.Lines = append(c.Lines, d.loc(i))
+				col, track = c.Lines[len(c.Lines)-1].Column, true
+				starts = false
+			}
+
+			// NOTE: If a line continues from one string (of a list) to the
+			// next, we only map the characters of its first part.
+			if track {
+				line := &c.Lines[len(c.Lines)-1]
+				line.Offsets = append(line.Offsets, col)
+			}
+
+			// An escape sequence is made of ASCII characters, so its size
+			// is also its width.
+			size, width := 1, 1
+			if d.src[i] == '\\' {
+				starts = i+1 < len(d.src) && d.src[i+1] == 'n'
+				size = escapeSize(d.src[i:])
+				width = size
+			} else {
+				_, size = utf8.DecodeRune(d.src[i:])
+			}
+
+			col += width
+			i += size
+		}
+		return nil
+	}
+
+	if q := d.peek(); q >= 0 && d.src[q] == '[' {
+		if err := d.array(read); err != nil {
+			return err
+		}
+	} else if err := read(); err != nil {
+		return err
+	}
+
+	c.Source = b.String()
+	return nil
+}
+
+// escapeSize returns the length of the escape sequence at the start of `b`,
+// which decodes to a single character: a UTF-16 surrogate pair (e.g.,
+// `\ud83d\ude00`) counts as one sequence.
+func escapeSize(b []byte) int {
+	if len(b) < 6 || b[1] != 'u' {
+		return 2
+	}
+
+	r, err := strconv.ParseUint(string(b[2:6]), 16, 16)
+	if err == nil && utf16.IsSurrogate(rune(r)) && len(b) >= 12 &&
+		b[6] == '\\' && b[7] == 'u' {
+		r2, err := strconv.ParseUint(string(b[8:12]), 16, 16)
+		if err == nil && utf16.DecodeRune(rune(r), rune(r2)) != utf8.RuneError {
+			return 12
+		}
+	}
+	return 6
+}
+
+// loc returns the location of the byte at offset `i`.
+func (d *decoder) loc(i int) Loc {
+	n := sort.Search(len(d.lines), func(j int) bool { return d.lines[j] > i })
+	return Loc{Line: n, Column: utf8.RuneCount(d.src[d.lines[n-1]:i])}
+}
+
+// peek returns the offset of the next value, or -1 if there isn't one.
+func (d *decoder) peek() int {
+	for i := int(d.InputOffset()); i < len(d.src); i++ {
+		if !strings.ContainsRune(" \t\r\n:,", rune(d.src[i])) {
+			return i
+		}
+	}
+	return -1
+}
+
+// object reads a JSON object, calling `fn` for each of its keys; `fn` must
+// consume the key's value.
+func (d *decoder) object(fn func(key string) error) error {
+	return d.compound('{', '}', func() error {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		return fn(key)
+	})
+}
+
+// array reads a JSON array, calling `fn` to consume each of its values.
+func (d *decoder) array(fn func() error) error {
+	return d.compound('[', ']', fn)
+}
+
+func (d *decoder) compound(open, close json.Delim, fn func() error) error {
+	tok, err := d.Token()
+	if err != nil {
+		return err
+	} else if tok == nil {
+		return nil
+	} else if tok != open {
+		return fmt.Errorf("expected '%v' at offset %d", open, d.InputOffset())
+	}
+
+	for d.More() {
+		if err = fn(); err != nil {
+			return err
+		}
+	}
+
+	_, err = d.Token()
+	return err
+}
+
+func (d *decoder) str(s *string) error {
+	tok, err := d.Token()
+	if err != nil {
+		return err
+	} else if tok == nil {
+		return nil
+	}
+
+	v, ok := tok.(string)
+	if !ok {
+		return fmt.Errorf("expected a string at offset %d", d.InputOffset())
+	}
+	*s = v
+
+	return nil
+}
+
+func (d *decoder) skip() error {
+	var v json.RawMessage
+	return d.Decode(&v)
+}
@@ -0,0 +1,93 @@
+package ipynb
+
+import (
+	"reflect"
+	"testing"
+)
+
+const notebook = `{
+ "cells": [
+  {
+   "cell_type": "markdown",
+   "metadata": {},
+   "source": [
+    "# Title\n",
+    "\n",
+    "Some \"quoted\" text."
+   ]
+  },
+  {
+   "cell_type": "code",
+   "execution_count": null,
+   "metadata": {},
+   "outputs": [],
+   "source": "x = 1\n# A comment"
+  }
+ ],
+ "metadata": {
+  "kernelspec": {"display_name": "Python 3", "language": "python", "name": "python3"},
+  "language_info": {"name": "python", "file_extension": ".py"}
+ },
+ "nbformat": 4,
+ "nbformat_minor": 4
+}`
+
+func TestParse(t *testing.T) {
+	nb, err := Parse([]byte(notebook))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if nb.Language != "python" || nb.Ext() != ".py" {
+		t.Errorf("unexpected language: %q (%q)", nb.Language, nb.Ext())
+	}
+
+	expected := []Cell{
+		{"markdown", "# Title\n\nSome \"quoted\" text.", []Loc{{7, 5, nil}, {8, 5, nil}, {9, 5, nil}}},
+		{"code", "x = 1\n# A comment", []Loc{{17, 14, nil}, {17, 21, nil}}},
+	}
+	for i := range nb.Cells {
+		for j := range nb.Cells[i].Lines {
+			nb.Cells[i].Lines[j].Offsets = nil
+		}
+	}
+	if !reflect.DeepEqual(nb.Cells, expected) {
+		t.Errorf("expected %v, got %v", expected, nb.Cells)
+	}
+}
+
+func TestCol(t *testing.T) {
+	nb, err := Parse([]byte(`{"cells": [
+  {"cell_type": "markdown", "source": ["Some \"quoted\" text\n", "caf\u00e9 \ud83d\ude00 \\ ok"]}
+]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := nb.Cells[0].Lines
+
+	// The "q" of "quoted" follows an escaped quote.
+	if col := lines[0].Col(7); col != 48 {
+		t.Errorf("expected 48, got %d", col)
+	}
+
+	// The "o" of "ok" follows a `\u` escape, a surrogate pair, and an
+	// escaped backslash.
+	if col := lines[1].Col(10); col != lines[1].Col(1)+26 {
+		t.Errorf("expected %d, got %d", lines[1].Col(1)+26, col)
+	}
+}
+
+func TestExt(t *testing.T) {
+	nb := Notebook{Language: "R"}
+	if nb.Ext() != ".r" {
+		t.Errorf("expected '.r', got %q", nb.Ext())
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	for _, src := range []string{`[]`, `{"cells": [{"source": 1}]}`, `{"cells": [`} {
+		if _, err := Parse([]byte(src)); err == nil {
+			t.Errorf("expected an error for %q", src)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package l10n parses localization files: gettext catalogs (`.po` and
+// `.pot`) and XLIFF documents (versions 1.2 and 2.0).
+package l10n
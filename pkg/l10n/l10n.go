@@ -0,0 +1,133 @@
+package l10n
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// A Loc is a location in a localization file.
+type Loc struct {
+	Line   int // the (1-based) line
+	Column int // the number of characters that precede the location
+}
+
+// An Entry is a translation unit -- e.g., a `.po` message or an XLIFF
+// `<trans-unit>`.
+type Entry struct {
+	ID     string // the msgid (prefixed by its msgctxt and '|') or unit ID
+	Source []Text // the source text (e.g., msgid and msgid_plural)
+	Target []Text // the translations (e.g., msgstr[0] and msgstr[1])
+}
+
+// A Text is a source or target string of an Entry.
+type Text struct {
+	Content string
+	Lang    string // the text's language (e.g., "fr"), if known
+	Lines   []Loc  // the location of each line of Content in the file
+
+	// chars holds the location of each character of each line of Content,
+	// which may span multiple lines in the file (e.g., `.po` strings).
+	chars [][]Loc
+}
+
+// Locate maps a line and column (both 1-based) in t's Content to their
+// location in the file.
+func (t Text) Locate(line, col int) (int, int) {
+	if line < 1 || line > len(t.Lines) {
+		return line, col
+	}
+
+	chars := t.chars[line-1]
+	if col >= 1 && col <= len(chars) {
+		return chars[col-1].Line, chars[col-1].Column + 1
+	} else if col > len(chars) && len(chars) > 0 {
+		last := chars[len(chars)-1]
+		return last.Line, last.Column + 1 + col - len(chars)
+	}
+
+	return t.Lines[line-1].Line, t.Lines[line-1].Column + col
+}
+
+// NormalizeLang converts a locale identifier (e.g., "pt_BR" or
+// "sr@latin") into a language tag (e.g., "pt-BR").
+func NormalizeLang(lang string) string {
+	lang = strings.TrimSpace(lang)
+	if i := strings.IndexAny(lang, ".@"); i >= 0 {
+		lang = lang[:i]
+	}
+	return strings.ReplaceAll(lang, "_", "-")
+}
+
+// doc is a localization file being parsed.
+type doc struct {
+	src   string
+	lines []int // the offset of each line in src
+	last  Loc   // the location of the byte at offset `off`
+	off   int
+}
+
+func newDoc(src string) *doc {
+	d := doc{src: src, lines: []int{0}}
+	for i := 0; i < len(src); i++ {
+		if src[i] == '\n' {
+			d.lines = append(d.lines, i+1)
+		}
+	}
+	return &d
+}
+
+// loc returns the location of the byte at offset `i`.
+//
+// Since we mostly read forward, we count from the last location we returned
+// when possible.
+func (d *doc) loc(i int) Loc {
+	n := sort.Search(len(d.lines), func(j int) bool { return d.lines[j] > i })
+	if n == d.last.Line && i >= d.off {
+		d.last.Column += utf8.RuneCountInString(d.src[d.off:i])
+	} else {
+		d.last = Loc{Line: n, Column: utf8.RuneCountInString(d.src[d.lines[n-1]:i])}
+	}
+	d.off = i
+	return d.last
+}
+
+// builder accumulates the content of a Text while recording the location of
+// each of its lines (and characters).
+type builder struct {
+	d     *doc
+	text  strings.Builder
+	lines []Loc
+	chars [][]Loc
+	open  bool // has the current line been located?
+}
+
+// add appends `s`, which was read from offset `i`.
+func (b *builder) add(s string, i int) {
+	loc := b.d.loc(i)
+	if !b.open {
+		b.lines = append(b.lines, loc)
+		b.chars = append(b.chars, nil)
+		b.open = true
+	}
+
+	chars := &b.chars[len(b.chars)-1]
+	for range s {
+		*chars = append(*chars, loc)
+	}
+	b.text.WriteString(s)
+}
+
+// newline ends the current line, which was read up to offset `i`.
+func (b *builder) newline(i int) {
+	if !b.open {
+		b.lines = append(b.lines, b.d.loc(i))
+		b.chars = append(b.chars, nil)
+	}
+	b.text.WriteByte('\n')
+	b.open = false
+}
+
+func (b *builder) result(lang string) Text {
+	return Text{Content: b.text.String(), Lang: lang, Lines: b.lines, chars: b.chars}
+}
@@ -0,0 +1,161 @@
+package l10n
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// flatten formats each entry's text as "id:source|target=content@line:col".
+func flatten(entries []Entry) []string {
+	found := []string{}
+	for _, e := range entries {
+		for kind, texts := range map[string][]Text{"source": e.Source, "target": e.Target} {
+			for _, t := range texts {
+				line, col := t.Locate(1, 1)
+				found = append(found, fmt.Sprintf("%s:%s(%s)=%s@%d:%d",
+					e.ID, kind, t.Lang, t.Content, line, col))
+			}
+		}
+	}
+	return found
+}
+
+func TestParsePO(t *testing.T) {
+	po := `# Translator comment.
+msgid ""
+msgstr ""
+"Language: pt_BR\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+
+#: src/main.c:10
+msgid "Open the file."
+msgstr "Abra o \"arquivo\"."
+
+msgctxt "menu"
+msgid "Open"
+msgstr ""
+"Abrir "
+"agora"
+
+msgid "One file"
+msgid_plural "%d files"
+msgstr[0] "Um arquivo"
+msgstr[1] "%d arquivos"
+
+#~ msgid "Old"
+#~ msgstr "Velho"
+`
+	entries, err := ParsePO(po)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := flatten(entries)
+	for _, s := range []string{
+		"Open the file.:source()=Open the file.@8:8",
+		`Open the file.:target(pt-BR)=Abra o "arquivo".@9:9`,
+		"menu|Open:target(pt-BR)=Abrir agora@14:2",
+		"One file:source()=%d files@18:15",
+		"One file:target(pt-BR)=%d arquivos@20:12",
+	} {
+		if !contains(found, s) {
+			t.Errorf("expected %q in %q", s, found)
+		}
+	}
+	if len(found) != 8 {
+		t.Errorf("expected 8 texts, got %d: %q", len(found), found)
+	}
+
+	// The second half of "Abrir agora" is on the next line.
+	if line, col := entries[1].Target[0].Locate(1, 7); line != 15 || col != 2 {
+		t.Errorf("expected 15:2, got %d:%d", line, col)
+	}
+	// Escaped quotes are two characters long in the file.
+	if line, col := entries[0].Target[0].Locate(1, 9); line != 9 || col != 18 {
+		t.Errorf("expected 9:18, got %d:%d", line, col)
+	}
+}
+
+func TestParseXLIFF(t *testing.T) {
+	xliff12 := `<?xml version="1.0" encoding="UTF-8"?>
+<xliff version="1.2" xmlns="urn:oasis:names:tc:xliff:document:1.2">
+  <file source-language="en-US" target-language="de_DE" datatype="plaintext" original="app">
+    <body>
+      <trans-unit id="greeting">
+        <source>Hello, <g id="1">world</g> &amp; <ph id="2">{name}</ph>!</source>
+        <target xml:lang="de-AT">Servus, Welt</target>
+        <note>A note.</note>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>`
+
+	entries, err := ParseXLIFF(xliff12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := flatten(entries)
+	for _, s := range []string{
+		"greeting:source(en-US)=Hello, world & !@6:17",
+		"greeting:target(de-AT)=Servus, Welt@7:34",
+	} {
+		if !contains(found, s) {
+			t.Errorf("expected %q in %q", s, found)
+		}
+	}
+	if line, col := entries[0].Source[0].Locate(1, 14); line != 6 || col != 44 {
+		t.Errorf("expected 6:44, got %d:%d", line, col)
+	}
+
+	xliff20 := `<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="en" trgLang="fr">
+ <file id="f1">
+  <unit id="u1">
+   <notes><note>Ignored.</note></notes>
+   <segment><source>First.</source><target>Premier.</target></segment>
+   <segment><source>Second <pc id="1">one</pc>.</source><target>Deuxième.</target></segment>
+  </unit>
+ </file>
+</xliff>`
+
+	entries, err = ParseXLIFF(xliff20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"u1:source(en)=First.@5:21",
+		"u1:source(en)=Second one.@6:21",
+		"u1:target(fr)=Premier.@5:44",
+		"u1:target(fr)=Deuxième.@6:65",
+	}
+	found = flatten(entries)
+	if len(found) != len(expected) {
+		t.Fatalf("expected %q, got %q", expected, found)
+	}
+	for _, s := range expected {
+		if !contains(found, s) {
+			t.Errorf("expected %q in %q", s, found)
+		}
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	if _, err := ParsePO("msgid \"a\nmsgstr \"b\""); err == nil {
+		t.Error("expected an error for an unterminated string")
+	}
+	if _, err := ParseXLIFF("<xliff><file>"); err == nil {
+		t.Error("expected an error for an unterminated element")
+	}
+}
+
+func TestNormalizeLang(t *testing.T) {
+	for in, out := range map[string]string{"pt_BR": "pt-BR", "sr@latin": "sr", "de_DE.UTF-8": "de-DE", "fr": "fr"} {
+		if NormalizeLang(in) != out {
+			t.Errorf("%s: expected %q, got %q", in, out, NormalizeLang(in))
+		}
+	}
+}
+
+func contains(found []string, s string) bool {
+	return strings.Contains("\x00"+strings.Join(found, "\x00")+"\x00", "\x00"+s+"\x00")
+}
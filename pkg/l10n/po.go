@@ -0,0 +1,207 @@
+package l10n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jdkato/regexp"
+)
+
+var (
+	rePOKeyword  = regexp.MustCompile(`^(msgctxt|msgid|msgid_plural|msgstr(?:\[\d+\])?)\s+"`)
+	rePOLanguage = regexp.MustCompile(`(?m)^Language:[ \t]*(\S+)`)
+)
+
+// poEntry is a `.po` message being parsed.
+type poEntry struct {
+	ctxt, id *builder
+	plural   *builder
+	strs     []*builder
+}
+
+type poParser struct {
+	*doc
+	entries []poEntry
+}
+
+// ParsePO parses the gettext catalog `src`.
+//
+// The catalog's header (the entry with an empty msgid) isn't returned, but
+// its `Language` is assigned to each translation. Obsolete (`#~`) entries
+// are ignored.
+func ParsePO(src string) ([]Entry, error) {
+	p := poParser{doc: newDoc(src)}
+
+	var cur *builder // the string that continuation lines belong to
+	for n, start := range p.lines {
+		end := len(src)
+		if n+1 < len(p.lines) {
+			end = p.lines[n+1] - 1
+		}
+
+		t := strings.TrimSpace(src[start:end])
+		off := start + strings.Index(src[start:end], t)
+
+		switch {
+		case t == "":
+			cur = nil
+			p.flush()
+		case strings.HasPrefix(t, "#"):
+			cur = nil
+			if len(p.entries) > 0 && p.last().id != nil {
+				// A comment precedes (and so starts) the next entry.
+				p.flush()
+			}
+		case strings.HasPrefix(t, `"`):
+			if cur == nil {
+				return nil, fmt.Errorf("line %d: unexpected string", n+1)
+			} else if err := p.str(cur, off, end); err != nil {
+				return nil, err
+			}
+		default:
+			m := rePOKeyword.FindStringSubmatch(t)
+			if m == nil {
+				return nil, fmt.Errorf("line %d: unexpected '%s'", n+1, t)
+			}
+
+			if len(p.entries) == 0 || (p.last().id != nil && (m[1] == "msgctxt" || m[1] == "msgid")) {
+				p.entries = append(p.entries, poEntry{})
+			}
+			e := &p.entries[len(p.entries)-1]
+
+			cur = &builder{d: p.doc}
+			switch m[1] {
+			case "msgctxt":
+				e.ctxt = cur
+			case "msgid":
+				e.id = cur
+			case "msgid_plural":
+				e.plural = cur
+			default:
+				e.strs = append(e.strs, cur)
+			}
+
+			if err := p.str(cur, off+len(m[0])-1, end); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return p.results(), nil
+}
+
+// flush ends the current entry (if any).
+func (p *poParser) flush() {
+	if len(p.entries) > 0 && p.last().id != nil {
+		p.entries = append(p.entries, poEntry{})
+	}
+}
+
+func (p *poParser) last() *poEntry {
+	return &p.entries[len(p.entries)-1]
+}
+
+func (p *poParser) results() []Entry {
+	lang := ""
+	for _, e := range p.entries {
+		if e.id != nil && e.ctxt == nil && e.id.text.Len() == 0 && len(e.strs) > 0 {
+			if m := rePOLanguage.FindStringSubmatch(e.strs[0].text.String()); m != nil {
+				lang = NormalizeLang(m[1])
+			}
+		}
+	}
+
+	entries := []Entry{}
+	for _, e := range p.entries {
+		if e.id == nil || (e.ctxt == nil && e.id.text.Len() == 0) {
+			continue
+		}
+
+		entry := Entry{ID: e.id.text.String()}
+		if e.ctxt != nil {
+			entry.ID = e.ctxt.text.String() + "|" + entry.ID
+		}
+
+		entry.Source = append(entry.Source, e.id.result(""))
+		if e.plural != nil {
+			entry.Source = append(entry.Source, e.plural.result(""))
+		}
+		for _, s := range e.strs {
+			entry.Target = append(entry.Target, s.result(lang))
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// str reads the quoted string that starts at offset `i` (and ends before
+// `end`) into `b`.
+func (p *poParser) str(b *builder, i, end int) error {
+	for i++; i < end; {
+		switch c := p.src[i]; c {
+		case '"':
+			return nil
+		case '\\':
+			s, n := unescape(p.src[i:end])
+			if s == "\n" {
+				b.newline(i)
+			} else {
+				b.add(s, i)
+			}
+			i += n
+		default:
+			_, n := utf8.DecodeRuneInString(p.src[i:])
+			b.add(p.src[i:i+n], i)
+			i += n
+		}
+	}
+	return fmt.Errorf("line %d: unterminated string", p.loc(i).Line)
+}
+
+// unescape decodes the C escape sequence at the start of `s`, returning its
+// value and length.
+func unescape(s string) (string, int) {
+	if len(s) < 2 {
+		return s, len(s)
+	}
+
+	switch c := s[1]; c {
+	case 'n':
+		return "\n", 2
+	case 't':
+		return "\t", 2
+	case 'r':
+		return "\r", 2
+	case 'a':
+		return "\a", 2
+	case 'b':
+		return "\b", 2
+	case 'f':
+		return "\f", 2
+	case 'v':
+		return "\v", 2
+	case 'x':
+		n := 2
+		for n < len(s) && n < 4 && strings.ContainsRune("0123456789abcdefABCDEF", rune(s[n])) {
+			n++
+		}
+		if v, err := strconv.ParseUint(s[2:n], 16, 8); err == nil {
+			return string(rune(v)), n
+		}
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		n := 1
+		for n < len(s) && n < 4 && s[n] >= '0' && s[n] <= '7' {
+			n++
+		}
+		if v, err := strconv.ParseUint(s[1:n], 8, 8); err == nil {
+			return string(rune(v)), n
+		}
+	}
+
+	r, n := utf8.DecodeRuneInString(s[1:])
+	return string(r), n + 1
+}
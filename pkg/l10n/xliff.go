@@ -0,0 +1,199 @@
+package l10n
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// skipped holds the elements whose content we don't lint.
+var skipped = map[string]bool{
+	// Elements that hold text other than the unit's source or target.
+	"alt-trans": true, "seg-source": true, "note": true, "notes": true,
+	"originalData": true,
+	// Inline elements that hold native code (XLIFF 1.2) or a sub-flow.
+	"bpt": true, "ept": true, "it": true, "ph": true, "sub": true,
+}
+
+type xliffParser struct {
+	*doc
+	dec *xml.Decoder
+}
+
+// ParseXLIFF parses the XLIFF (1.2 or 2.0) document `src`.
+//
+// Each `<trans-unit>` (1.2) or `<unit>` (2.0) is an Entry whose text is
+// taken from its `<source>` and `<target>` elements (one per segment, in
+// 2.0), without any inline markup. Languages are taken from the `xml:lang`
+// of each element or, by default, from the document's (or `<file>`'s)
+// source and target languages.
+func ParseXLIFF(src string) ([]Entry, error) {
+	p := xliffParser{doc: newDoc(src), dec: xml.NewDecoder(strings.NewReader(src))}
+
+	var srcLang, trgLang string
+	var entry *Entry
+
+	entries := []Entry{}
+	for {
+		tok, err := p.dec.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch name := t.Name.Local; {
+			case name == "xliff" || name == "file":
+				for _, attr := range t.Attr {
+					switch attr.Name.Local {
+					case "srcLang", "source-language":
+						srcLang = NormalizeLang(attr.Value)
+					case "trgLang", "target-language":
+						trgLang = NormalizeLang(attr.Value)
+					}
+				}
+			case name == "trans-unit" || name == "unit":
+				entry = &Entry{ID: attribute(t, "id")}
+			case skipped[name]:
+				if err = p.dec.Skip(); err != nil {
+					return nil, err
+				}
+			case name == "source" && entry != nil:
+				text, err := p.text(t, srcLang)
+				if err != nil {
+					return nil, err
+				}
+				entry.Source = append(entry.Source, text)
+			case name == "target" && entry != nil:
+				text, err := p.text(t, trgLang)
+				if err != nil {
+					return nil, err
+				}
+				entry.Target = append(entry.Target, text)
+			}
+		case xml.EndElement:
+			if name := t.Name.Local; (name == "trans-unit" || name == "unit") && entry != nil {
+				entries = append(entries, *entry)
+				entry = nil
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// text reads the content of the element `start`, whose language defaults to
+// `lang`.
+func (p *xliffParser) text(start xml.StartElement, lang string) (Text, error) {
+	b := builder{d: p.doc}
+	if l := attribute(start, "lang"); l != "" {
+		lang = NormalizeLang(l)
+	}
+
+	for depth := 0; ; {
+		off := int(p.dec.InputOffset())
+		tok, err := p.dec.Token()
+		if err != nil {
+			return Text{}, err
+		}
+
+		switch t := tok.(type) {
+		case xml.CharData:
+			p.chars(&b, off, int(p.dec.InputOffset()))
+		case xml.StartElement:
+			if skipped[t.Name.Local] {
+				if err = p.dec.Skip(); err != nil {
+					return Text{}, err
+				}
+				continue
+			}
+			depth++
+		case xml.EndElement:
+			if depth == 0 {
+				return b.result(lang), nil
+			}
+			depth--
+		}
+	}
+}
+
+// chars reads the character data between offsets `start` and `end`.
+func (p *xliffParser) chars(b *builder, start, end int) {
+	raw := p.src[start:end]
+	if strings.HasPrefix(raw, "<![CDATA[") {
+		start, end = start+len("<![CDATA["), end-len("]]>")
+		for i := start; i < end; {
+			i += p.char(b, i)
+		}
+		return
+	}
+
+	for i := start; i < end; {
+		if p.src[i] == '&' {
+			if j := strings.IndexByte(p.src[i:end], ';'); j > 0 {
+				if s := entity(p.src[i+1 : i+j]); s != "" {
+					b.add(s, i)
+					i += j + 1
+					continue
+				}
+			}
+		}
+		i += p.char(b, i)
+	}
+}
+
+// char reads the character at offset `i`, returning its length.
+func (p *xliffParser) char(b *builder, i int) int {
+	switch p.src[i] {
+	case '\n':
+		b.newline(i)
+		return 1
+	case '\r':
+		return 1
+	}
+	_, n := utf8.DecodeRuneInString(p.src[i:])
+	b.add(p.src[i:i+n], i)
+	return n
+}
+
+// entity returns the value of the named or numeric character reference
+// `name` (without its '&' and ';'), or an empty string if it isn't one.
+func entity(name string) string {
+	switch name {
+	case "amp":
+		return "&"
+	case "lt":
+		return "<"
+	case "gt":
+		return ">"
+	case "quot":
+		return `"`
+	case "apos":
+		return "'"
+	}
+
+	if strings.HasPrefix(name, "#x") || strings.HasPrefix(name, "#X") {
+		if v, err := strconv.ParseUint(name[2:], 16, 32); err == nil {
+			return string(rune(v))
+		}
+	} else if strings.HasPrefix(name, "#") {
+		if v, err := strconv.ParseUint(name[1:], 10, 32); err == nil {
+			return string(rune(v))
+		}
+	}
+
+	return ""
+}
+
+func attribute(elem xml.StartElement, name string) string {
+	for _, attr := range elem.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
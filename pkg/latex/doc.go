@@ -0,0 +1,2 @@
+// Package latex implements a pure-Go converter from LaTeX to HTML.
+package latex
@@ -0,0 +1,655 @@
+package latex
+
+import (
+	"html"
+	"strings"
+)
+
+// A Document is a converted LaTeX document.
+type Document struct {
+	HTML   string // the HTML content
+	Masked string // the source, with everything but its content masked
+}
+
+type env struct {
+	name string
+	tag  string // the HTML tag (if any) that represents the environment
+	open bool   // whether a list item (or table row) is open
+}
+
+type parser struct {
+	src    string
+	pos    int
+	hidden []bool // the source bytes that aren't part of the output
+	skip   map[string]bool
+
+	out   strings.Builder
+	para  *strings.Builder // the current paragraph (or heading, etc.)
+	stack []env
+	notes []string
+
+	title   string
+	discard bool // whether we're in the preamble
+	done    bool // whether we've seen `\end{document}`
+}
+
+// Convert converts the LaTeX document `src` into HTML, skipping the given
+// macros (e.g., "\foo") along with their arguments.
+//
+// Since the HTML doesn't include commands, math, or comments, we also return
+// a masked copy of `src` (with the same lines and the same number of
+// characters on each line) in which they've been replaced by asterisks. This
+// allows us to avoid matching their text when locating alerts.
+func Convert(src string, macros []string) Document {
+	p := parser{
+		src:     src,
+		hidden:  make([]bool, len(src)),
+		skip:    make(map[string]bool),
+		para:    &strings.Builder{},
+		discard: strings.Contains(src, `\begin{document}`),
+	}
+
+	for i := range p.hidden {
+		p.hidden[i] = true
+	}
+	for _, name := range skipped {
+		p.skip[name] = true
+	}
+	for _, name := range macros {
+		p.skip[strings.TrimPrefix(strings.TrimSpace(name), `\`)] = true
+	}
+
+	p.parse(0)
+	for len(p.stack) > 0 {
+		p.close()
+	}
+	p.flush()
+
+	return Document{HTML: p.out.String(), Masked: p.masked()}
+}
+
+// parse converts the source up to (and including) `stop`, if given.
+func (p *parser) parse(stop byte) {
+	for p.pos < len(p.src) && !p.done {
+		ch := p.src[p.pos]
+		switch {
+		case ch == stop:
+			p.pos++
+			return
+		case ch == '\\':
+			p.command()
+		case ch == '%':
+			p.comment()
+		case ch == '$':
+			p.dollars()
+		case ch == '{':
+			p.pos++
+			p.parse('}')
+		case ch == '}':
+			p.pos++
+		case ch == '&' && p.inTable():
+			p.pos++
+			p.cell()
+		case ch == '~':
+			p.para.WriteByte(' ')
+			p.pos++
+		case ch == '\n' && p.blankLine():
+			p.pos++
+			p.flush()
+		default:
+			end := p.pos + 1
+			for end < len(p.src) && !strings.ContainsRune("\\%${}&~\n]", rune(p.src[end])) {
+				end++
+			}
+			p.text(p.pos, end)
+			p.pos = end
+		}
+	}
+}
+
+// text writes the source text between `start` and `end`.
+func (p *parser) text(start, end int) {
+	if p.discard {
+		return
+	}
+	p.para.WriteString(html.EscapeString(p.src[start:end]))
+	for i := start; i < end; i++ {
+		p.hidden[i] = false
+	}
+}
+
+func (p *parser) command() {
+	p.pos++
+	if p.pos >= len(p.src) {
+		return
+	}
+
+	ch := p.src[p.pos]
+	if !isLetter(ch) {
+		p.pos++
+		p.symbol(ch)
+		return
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && isLetter(p.src[p.pos]) {
+		p.pos++
+	}
+	name := p.src[start:p.pos]
+	if p.pos < len(p.src) && p.src[p.pos] == '*' {
+		p.pos++
+	}
+
+	if tag, found := sections[name]; found {
+		p.heading(name, tag)
+	} else if tag, found := fonts[name]; found {
+		p.para.WriteString("<" + tag + ">")
+		p.group()
+		p.para.WriteString("</" + tag + ">")
+	} else if p.skip[name] {
+		p.skipArgs(-1)
+	} else if n, found := skippedArgs[name]; found {
+		p.skipArgs(n)
+	} else if inSlice(name, literals) {
+		if start, end, ok := p.rawGroup(); ok {
+			p.para.WriteString("<code>")
+			p.text(start, end)
+			p.para.WriteString("</code>")
+		}
+	} else {
+		p.macro(name)
+	}
+}
+
+// macro handles the commands that need special treatment.
+func (p *parser) macro(name string) {
+	switch name {
+	case "begin":
+		if start, end, ok := p.rawGroup(); ok {
+			p.begin(p.src[start:end])
+		}
+	case "end":
+		if start, end, ok := p.rawGroup(); ok {
+			p.end(p.src[start:end])
+		}
+	case "item":
+		p.item()
+	case "par":
+		p.flush()
+	case "verb", "lstinline":
+		p.verb()
+	case "href":
+		if start, end, ok := p.rawGroup(); ok {
+			p.para.WriteString(`<a href="` + html.EscapeString(p.src[start:end]) + `">`)
+			p.group()
+			p.para.WriteString("</a>")
+		}
+	case "footnote":
+		p.optional()
+		p.notes = append(p.notes, p.capture(p.group))
+	case "caption":
+		p.optional()
+		p.flush()
+		if caption := p.capture(p.group); !p.discard {
+			p.out.WriteString("<p>" + caption + "</p>\n")
+		}
+	case "def":
+		// `\def\name#1{...}`
+		for p.pos < len(p.src) && p.src[p.pos] != '{' {
+			p.pos++
+		}
+		p.rawGroup()
+	default:
+		// An unknown command (e.g., `\LaTeX`): we skip its optional argument
+		// and treat any other arguments as text.
+		if p.pos < len(p.src) && p.src[p.pos] == '[' {
+			p.optional()
+		}
+	}
+}
+
+// symbol handles a control symbol (e.g., `\%`).
+func (p *parser) symbol(ch byte) {
+	switch ch {
+	case '%', '$', '&', '#', '_', '{', '}':
+		p.text(p.pos-1, p.pos)
+	case '\\':
+		p.optional()
+		if p.inTable() {
+			p.row()
+		} else {
+			p.para.WriteByte('\n')
+		}
+	case ' ', ',', ';', ':':
+		p.para.WriteByte(' ')
+	case '(':
+		p.math(`\)`, true)
+	case '[':
+		p.math(`\]`, false)
+	case '\'', '"', '^', '`', '~', '=', '.':
+		// An accent: we keep the letter.
+		if start, end, ok := p.rawGroup(); ok {
+			p.text(start, end)
+		} else if p.pos < len(p.src) && isLetter(p.src[p.pos]) {
+			p.text(p.pos, p.pos+1)
+			p.pos++
+		}
+	}
+}
+
+func (p *parser) heading(name, tag string) {
+	p.optional()
+	if name == "title" && p.discard {
+		discard := p.discard
+		p.discard = false
+		p.title = p.capture(p.group)
+		p.discard = discard
+		return
+	}
+
+	p.flush()
+	if heading := p.capture(p.group); !p.discard {
+		p.out.WriteString("<" + tag + ">" + heading + "</" + tag + ">\n")
+	}
+}
+
+func (p *parser) begin(name string) {
+	switch {
+	case name == "document":
+		p.discard = false
+		p.para.Reset()
+		if p.title != "" {
+			p.out.WriteString("<h1>" + p.title + "</h1>\n")
+		}
+	case inSlice(name, skippedEnvs):
+		p.raw(name)
+	case inSlice(name, verbatimEnvs):
+		p.flush()
+		start, end := p.raw(name)
+		if !p.discard {
+			p.out.WriteString("<pre>")
+			p.para.Reset()
+			p.text(start, end)
+			p.out.WriteString(p.para.String() + "</pre>\n")
+			p.para.Reset()
+		}
+	default:
+		p.flush()
+		p.optional()
+		for i := 0; i < envArgs[name]; i++ {
+			p.rawGroup()
+		}
+
+		tag := envTags[name]
+		if tag != "" && !p.discard {
+			p.out.WriteString("<" + tag + ">\n")
+		}
+		p.stack = append(p.stack, env{name: name, tag: tag})
+	}
+}
+
+func (p *parser) end(name string) {
+	if name == "document" {
+		p.done = true
+		return
+	}
+
+	for i := len(p.stack) - 1; i >= 0; i-- {
+		if p.stack[i].name == name {
+			for len(p.stack) > i {
+				p.close()
+			}
+			return
+		}
+	}
+}
+
+// close closes the innermost open environment.
+func (p *parser) close() {
+	top := &p.stack[len(p.stack)-1]
+	switch top.tag {
+	case "table":
+		if top.open || strings.TrimSpace(p.para.String()) != "" {
+			p.row()
+		}
+	case "ul", "ol":
+		p.flush()
+		if top.open {
+			p.write("</li>\n")
+		}
+	default:
+		p.flush()
+	}
+
+	if top.tag != "" {
+		p.write("</" + top.tag + ">\n")
+	}
+	p.stack = p.stack[:len(p.stack)-1]
+}
+
+func (p *parser) item() {
+	top := p.top()
+	if top == nil || (top.tag != "ul" && top.tag != "ol") {
+		p.flush()
+		return
+	}
+
+	p.flush()
+	if top.open {
+		p.write("</li>\n")
+	}
+	p.write("<li>")
+	top.open = true
+
+	if p.peek() == '[' {
+		p.pos = p.skipSpace()
+		label := p.capture(func() {
+			p.pos++
+			p.parse(']')
+		})
+		p.para.WriteString("<strong>" + label + "</strong>")
+	}
+}
+
+// cell ends the current table cell.
+func (p *parser) cell() {
+	top := p.top()
+	if !top.open {
+		p.write("<tr>")
+		top.open = true
+	}
+	p.write("<td>" + p.para.String() + "</td>")
+	p.para.Reset()
+}
+
+// row ends the current table row.
+func (p *parser) row() {
+	p.cell()
+	p.write("</tr>\n")
+	p.top().open = false
+}
+
+// flush writes the current paragraph and any pending footnotes.
+func (p *parser) flush() {
+	top := p.top()
+	if p.discard {
+		p.para.Reset()
+		p.notes = nil
+		return
+	} else if top != nil && top.tag == "table" {
+		return
+	}
+
+	text := p.para.String()
+	p.para.Reset()
+
+	if strings.TrimSpace(text) != "" {
+		if top != nil && top.open {
+			p.out.WriteString(text)
+		} else {
+			p.out.WriteString("<p>" + text + "</p>\n")
+		}
+	}
+
+	for _, note := range p.notes {
+		p.out.WriteString("<p>" + note + "</p>\n")
+	}
+	p.notes = nil
+}
+
+func (p *parser) write(s string) {
+	if !p.discard {
+		p.out.WriteString(s)
+	}
+}
+
+func (p *parser) comment() {
+	line := strings.LastIndexByte(p.src[:p.pos], '\n') + 1
+	alone := strings.TrimSpace(p.src[line:p.pos]) == ""
+
+	start := p.pos + 1
+	end := strings.IndexByte(p.src[start:], '\n')
+	if end < 0 {
+		end = len(p.src)
+	} else {
+		end += start
+	}
+	p.pos = end
+
+	comment := strings.TrimSpace(p.src[start:end])
+	if !strings.HasPrefix(comment, "vale ") || p.discard {
+		return
+	} else if alone && !p.inTable() {
+		// A comment on its own line ends the current paragraph (so that it
+		// applies to the following content).
+		p.flush()
+		p.out.WriteString("<!-- " + comment + " -->\n")
+	} else {
+		p.para.WriteString("<!-- " + comment + " -->")
+	}
+}
+
+// dollars handles `$...$` and `$$...$$`.
+func (p *parser) dollars() {
+	if strings.HasPrefix(p.src[p.pos:], "$$") {
+		p.pos += 2
+		p.math("$$", false)
+	} else {
+		p.pos++
+		p.math("$", true)
+	}
+}
+
+// math skips the math up to `delim`, rendering inline math as code.
+func (p *parser) math(delim string, inline bool) {
+	start, end := p.pos, len(p.src)
+	for p.pos < len(p.src) {
+		if strings.HasPrefix(p.src[p.pos:], delim) {
+			end = p.pos
+			p.pos += len(delim)
+			break
+		} else if p.src[p.pos] == '\\' {
+			p.pos++
+		}
+		p.pos++
+	}
+
+	if p.pos > len(p.src) {
+		p.pos = len(p.src)
+	}
+
+	if inline && end > start {
+		p.para.WriteString("<code>")
+		p.text(start, end)
+		p.para.WriteString("</code>")
+	}
+}
+
+// verb handles `\verb|...|` (and `\lstinline`).
+func (p *parser) verb() {
+	if start, end, ok := p.rawGroup(); ok {
+		p.para.WriteString("<code>")
+		p.text(start, end)
+		p.para.WriteString("</code>")
+		return
+	} else if p.pos >= len(p.src) {
+		return
+	}
+
+	delim := p.src[p.pos]
+	end := strings.IndexByte(p.src[p.pos+1:], delim)
+	if end < 0 {
+		return
+	}
+
+	start := p.pos + 1
+	p.pos = start + end + 1
+
+	p.para.WriteString("<code>")
+	p.text(start, start+end)
+	p.para.WriteString("</code>")
+}
+
+// raw skips the content of the environment `name`, returning its bounds.
+func (p *parser) raw(name string) (int, int) {
+	start := p.pos
+
+	end := strings.Index(p.src[start:], `\end{`+name+`}`)
+	if end < 0 {
+		p.pos = len(p.src)
+		return start, p.pos
+	}
+
+	p.pos = start + end + len(`\end{`+name+`}`)
+	return start, start + end
+}
+
+// group converts the next argument (if any).
+func (p *parser) group() {
+	if p.peek() == '{' {
+		p.pos = p.skipSpace() + 1
+		p.parse('}')
+	}
+}
+
+// rawGroup skips the next argument (if any), returning its bounds.
+func (p *parser) rawGroup() (int, int, bool) {
+	if p.peek() != '{' {
+		return 0, 0, false
+	}
+	p.pos = p.skipSpace()
+	return p.delimited('{', '}')
+}
+
+// optional skips the next optional argument (if any).
+func (p *parser) optional() {
+	if p.peek() == '[' {
+		p.pos = p.skipSpace()
+		p.delimited('[', ']')
+	}
+}
+
+// skipArgs skips up to `n` arguments (or all of them if `n` is negative),
+// along with any optional arguments.
+func (p *parser) skipArgs(n int) {
+	for i := 0; n < 0 || i < n; {
+		switch p.peek() {
+		case '[':
+			p.optional()
+		case '{':
+			p.rawGroup()
+			i++
+		default:
+			return
+		}
+	}
+}
+
+// delimited skips a balanced, delimited argument starting at the current
+// position, returning the bounds of its content.
+func (p *parser) delimited(open, close byte) (int, int, bool) {
+	start, depth := p.pos+1, 0
+	for i := p.pos; i < len(p.src); i++ {
+		switch p.src[i] {
+		case '\\':
+			i++
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				p.pos = i + 1
+				return start, i, true
+			}
+		}
+	}
+	p.pos = len(p.src)
+	return start, len(p.src), true
+}
+
+// capture returns the output written by `fn`.
+func (p *parser) capture(fn func()) string {
+	para := p.para
+	p.para = &strings.Builder{}
+	fn()
+	s := p.para.String()
+	p.para = para
+	return s
+}
+
+// peek returns the next non-space character, within the current paragraph.
+func (p *parser) peek() byte {
+	if i := p.skipSpace(); i < len(p.src) {
+		return p.src[i]
+	}
+	return 0
+}
+
+func (p *parser) skipSpace() int {
+	i, lines := p.pos, 0
+	for i < len(p.src) && strings.IndexByte(" \t\r\n", p.src[i]) >= 0 {
+		if p.src[i] == '\n' {
+			lines++
+			if lines > 1 {
+				break
+			}
+		}
+		i++
+	}
+	return i
+}
+
+// blankLine reports whether the newline at the current position ends a
+// paragraph.
+func (p *parser) blankLine() bool {
+	for i := p.pos + 1; i < len(p.src); i++ {
+		switch p.src[i] {
+		case '\n':
+			return true
+		case ' ', '\t', '\r':
+			continue
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (p *parser) inTable() bool {
+	top := p.top()
+	return top != nil && top.tag == "table"
+}
+
+func (p *parser) top() *env {
+	if len(p.stack) == 0 {
+		return nil
+	}
+	return &p.stack[len(p.stack)-1]
+}
+
+// masked returns the source with its hidden characters replaced by
+// asterisks.
+func (p *parser) masked() string {
+	var b strings.Builder
+	for i, r := range p.src {
+		if p.hidden[i] && r != '\n' && r != '\r' {
+			b.WriteByte('*')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func isLetter(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || ch == '@'
+}
+
+func inSlice(s string, slice []string) bool {
+	for _, t := range slice {
+		if t == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package latex
+
+import (
+	"strings"
+	"testing"
+)
+
+var htmlTests = []struct {
+	tex      string
+	contains []string
+	excludes []string
+}{
+	{"\\title{Report}\n\\begin{document}\n\\section{One}\n\\subsection*{Two}\nText.\n\\end{document}", []string{
+		"<h1>Report</h1>", "<h2>One</h2>", "<h3>Two</h3>", "<p>\nText.\n</p>"}, nil},
+	{"\\documentclass{article}\n\\usepackage{TODO}\n\\begin{document}\nText.\n\\end{document}\nTODO", []string{
+		"<p>\nText.\n</p>"}, []string{"TODO", "article"}},
+	{"Use \\emph{this}, \\textbf{that} and \\texttt{x\\_y}.", []string{
+		"<em>this</em>", "<strong>that</strong>", "<code>x_y</code>"}, nil},
+	{"\\begin{enumerate}\n\\item One\n\\item[Two] Three\n\\end{enumerate}", []string{
+		"<ol>", "<li> One\n</li>", "<li><strong>Two</strong> Three\n</li>"}, nil},
+	{"\\begin{quote}\nA quote.\n\\end{quote}", []string{
+		"<blockquote>\n<p>\nA quote.\n</p>\n</blockquote>"}, nil},
+	{"\\begin{tabular}{ll}\nA & B \\\\\n\\hline\n\\multicolumn{2}{c}{C} \\\\\n\\end{tabular}", []string{
+		"<td>\nA </td><td> B </td></tr>", "<td>\n\nC </td></tr>"}, []string{"ll", "hline"}},
+	{"Let $x$ be \\(y\\), where \\[z = 1\\] and $$q$$.\n\\begin{align}\na &= b\n\\end{align}", []string{
+		"Let <code>x</code> be <code>y</code>, where  and ."}, []string{"z =", "q", "a &amp;= b"}},
+	{"\\begin{lstlisting}[language=Go]\nx := 1\n\\end{lstlisting}\n\\verb|y| and \\url{http://a_b.com}", []string{
+		"<pre>[language=Go]\nx := 1\n</pre>", "<code>y</code>", "<code>http://a_b.com</code>"}, nil},
+	{"See \\cite[p.~1]{knuth} and \\ref{fig}\\label{sec} or \\href{http://x.com}{the site}.", []string{
+		`See  and  or <a href="http://x.com">the site</a>.`}, []string{"knuth", "fig"}},
+	{"Text\\footnote{A note.} here.\n% A comment.\n\n% vale off\nMore.", []string{
+		"<p>Text here.\n</p>\n<p>A note.</p>\n<!-- vale off -->"}, []string{"comment"}},
+	{"A \\todo{fix this} and \\note[x]{keep this}.", []string{"keep this"}, []string{"fix this"}},
+}
+
+func TestConvert(t *testing.T) {
+	for _, tt := range htmlTests {
+		doc := Convert(tt.tex, []string{`\todo`})
+		for _, s := range tt.contains {
+			if !strings.Contains(doc.HTML, s) {
+				t.Errorf("%q: expected %q in:\n%s", tt.tex, s, doc.HTML)
+			}
+		}
+		for _, s := range tt.excludes {
+			if strings.Contains(doc.HTML, s) {
+				t.Errorf("%q: unexpected %q in:\n%s", tt.tex, s, doc.HTML)
+			}
+		}
+	}
+}
+
+func TestMasked(t *testing.T) {
+	src := "\\section{Intro}\nCaf\\'e costs 50\\% % TODO\n"
+
+	masked := Convert(src, nil).Masked
+	if masked != "*********Intro*\nCaf**e costs 50*% ******\n" {
+		t.Errorf("unexpected masked source: %q", masked)
+	}
+}
@@ -0,0 +1,96 @@
+package latex
+
+// sections maps sectioning commands to their heading tags.
+var sections = map[string]string{
+	"title":         "h1",
+	"part":          "h1",
+	"chapter":       "h1",
+	"section":       "h2",
+	"subsection":    "h3",
+	"subsubsection": "h4",
+	"paragraph":     "h5",
+	"subparagraph":  "h6",
+}
+
+// fonts maps font commands to their inline tags.
+var fonts = map[string]string{
+	"emph":   "em",
+	"textit": "em",
+	"textsl": "em",
+	"textbf": "strong",
+	"texttt": "code",
+}
+
+// literals are commands whose argument is read verbatim.
+var literals = []string{"url", "nolinkurl", "path"}
+
+// skipped are commands that are skipped along with all of their arguments.
+var skipped = []string{
+	"addbibresource", "addtocounter", "addtolength", "appendix", "author",
+	"autocite", "autoref", "bibitem", "bibliography", "bibliographystyle",
+	"bottomrule", "centering", "cite", "citeauthor", "citep", "citet",
+	"citeyear", "clearpage", "cline", "cmidrule", "Cref", "cref", "date",
+	"DeclareMathOperator", "documentclass", "eqref", "footcite",
+	"graphicspath", "hline", "hspace", "hypersetup", "include",
+	"includegraphics", "index", "input", "label", "linebreak",
+	"listoffigures", "listoftables", "maketitle", "midrule", "nameref",
+	"newcommand", "newenvironment", "newpage", "newtheorem", "nocite",
+	"noindent", "pagebreak", "pageref", "pagestyle", "parencite",
+	"providecommand", "ref", "renewcommand", "renewenvironment",
+	"RequirePackage", "setcounter", "setlength", "tableofcontents",
+	"textcite", "thanks", "thispagestyle", "toprule", "usepackage",
+	"vspace",
+}
+
+// skippedArgs are commands whose first few (non-prose) arguments are skipped.
+var skippedArgs = map[string]int{
+	"colorbox":        1,
+	"fcolorbox":       2,
+	"foreignlanguage": 1,
+	"multicolumn":     2,
+	"multirow":        2,
+	"raisebox":        1,
+	"resizebox":       2,
+	"scalebox":        1,
+	"textcolor":       1,
+}
+
+// envTags maps environments to the HTML tags that represent them.
+var envTags = map[string]string{
+	"itemize":     "ul",
+	"enumerate":   "ol",
+	"description": "ul",
+	"quote":       "blockquote",
+	"quotation":   "blockquote",
+	"verse":       "blockquote",
+	"tabular":     "table",
+	"tabular*":    "table",
+	"tabularx":    "table",
+	"tabulary":    "table",
+	"longtable":   "table",
+}
+
+// envArgs are the number of required (non-prose) arguments taken by an
+// environment.
+var envArgs = map[string]int{
+	"longtable":  1,
+	"minipage":   1,
+	"multicols":  1,
+	"tabular":    1,
+	"tabular*":   2,
+	"tabularx":   2,
+	"tabulary":   2,
+	"wrapfigure": 2,
+}
+
+// verbatimEnvs are environments whose content is rendered as a code block.
+var verbatimEnvs = []string{
+	"lstlisting", "minted", "verbatim", "verbatim*", "Verbatim"}
+
+// skippedEnvs are environments whose content is removed.
+var skippedEnvs = []string{
+	"align", "align*", "alignat", "alignat*", "comment", "displaymath",
+	"eqnarray", "eqnarray*", "equation", "equation*", "flalign", "flalign*",
+	"gather", "gather*", "math", "multline", "multline*", "picture",
+	"thebibliography", "tikzpicture",
+}
@@ -0,0 +1,2 @@
+// Package mdx converts MDX documents into plain Markdown.
+package mdx
@@ -0,0 +1,354 @@
+package mdx
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	reFence   = regexp.MustCompile("^(`{3,}|~{3,})")
+	reESM     = regexp.MustCompile(`^(?:import|export)\b`)
+	reName    = regexp.MustCompile(`^</?\s*([\w.:-]*)`)
+	reAttr    = regexp.MustCompile(`([\w:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	reComment = regexp.MustCompile(`(?s)^\{\s*/\*\s*(vale\b.*?)\s*\*/\s*\}$`)
+)
+
+// A Prop is a string-valued attribute of a JSX element -- e.g., the `title` in
+// `<Card title="Get started">`.
+type Prop struct {
+	Component string // the element's name (e.g., "Card")
+	Name      string // the attribute's name (e.g., "title")
+	Value     string // the attribute's value (e.g., "Get started")
+	Line      int    // the 1-based line on which the value starts
+}
+
+// A Document is a converted MDX document.
+type Document struct {
+	Markdown string // the Markdown content
+	Masked   string // the source, with everything but its content masked
+	Props    []Prop // the requested props
+}
+
+const (
+	modeText = iota
+	modeTag
+	modeExpr
+)
+
+type converter struct {
+	out    strings.Builder
+	hidden []bool // the source bytes to mask
+	names  []string
+	props  []Prop
+
+	line  int    // the current (1-based) line
+	depth int    // the number of open JSX elements
+	fence string // the marker of the fenced code block we're in (if any)
+	esm   bool   // whether we're in an ESM block
+	blank bool   // whether the previous line was blank
+
+	// The state of a tag or expression, which may span multiple lines.
+	mode    int
+	braces  int
+	quote   byte
+	comment bool
+	start   int // the line on which the tag or expression started
+	from    int // the offset at which the tag or expression started
+	buf     strings.Builder
+}
+
+// Convert converts the MDX document `src` into Markdown, collecting the props
+// whose names (either `name` or `Component.name`) are listed in `names`.
+//
+// Since the Markdown doesn't include ESM blocks, expressions, or tags, we also
+// return a masked copy of `src` (with the same lines and the same number of
+// characters on each line) in which they've been replaced by asterisks. This
+// allows us to avoid matching their text when locating alerts.
+func Convert(src string, names []string) Document {
+	c := converter{names: names, blank: true, hidden: make([]bool, len(src))}
+
+	off := 0
+	for i, line := range strings.SplitAfter(src, "\n") {
+		c.line = i + 1
+		base := off
+		off += len(line)
+
+		blank := c.blank
+		c.blank = strings.TrimSpace(line) == ""
+
+		if c.mode != modeText {
+			c.scan(line, base)
+			continue
+		}
+
+		text := strings.TrimRight(line, "\r\n")
+		trimmed := strings.TrimLeft(text, " \t")
+		if c.fence != "" {
+			c.out.WriteString(line)
+			if strings.HasPrefix(trimmed, c.fence) && strings.Trim(trimmed, c.fence[:1]) == "" {
+				c.fence = ""
+			}
+			continue
+		} else if c.esm || (c.depth == 0 && blank && reESM.MatchString(text)) {
+			// ESM blocks end at the first blank line.
+			c.esm = !c.blank
+			c.out.WriteString("\n")
+			c.mask(base, base+len(text))
+			continue
+		}
+
+		if c.depth > 0 {
+			// The content of JSX elements is commonly indented, which would
+			// otherwise turn it into an (indented) code block.
+			line = strings.TrimLeft(line, " \t")
+			base = off - len(line)
+		}
+
+		if m := reFence.FindString(trimmed); m != "" {
+			c.fence = m
+			c.out.WriteString(line)
+			continue
+		}
+
+		c.scan(line, base)
+	}
+
+	return Document{
+		Markdown: c.out.String(),
+		Masked:   c.masked(src),
+		Props:    c.props,
+	}
+}
+
+// scan handles `line`, which starts at the offset `base` in the source.
+func (c *converter) scan(line string, base int) {
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch c.mode {
+		case modeTag, modeExpr:
+			c.buf.WriteByte(ch)
+			if ch == '\n' {
+				c.out.WriteByte(ch)
+			}
+			c.step(line, &i)
+		default:
+			i = c.text(line, i) - 1
+			if c.mode != modeText {
+				c.from = base + i
+			}
+		}
+	}
+}
+
+// mask marks the source text between `start` and `end` as hidden.
+func (c *converter) mask(start, end int) {
+	for i := start; i < end; i++ {
+		c.hidden[i] = true
+	}
+}
+
+// masked returns `src` with its hidden characters replaced by asterisks.
+func (c *converter) masked(src string) string {
+	var b strings.Builder
+	for i, r := range src {
+		if c.hidden[i] && r != '\n' && r != '\r' {
+			b.WriteByte('*')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// text handles the character at `line[i]` outside of tags and expressions,
+// returning the index of the next character to handle.
+func (c *converter) text(line string, i int) int {
+	ch := line[i]
+	switch {
+	case ch == '\\' && i+1 < len(line):
+		c.out.WriteString(line[i : i+2])
+		return i + 2
+	case ch == '`':
+		n := run(line, i, '`')
+		end := i + n
+		for end < len(line) {
+			next := strings.Index(line[end:], strings.Repeat("`", n))
+			if next < 0 {
+				break
+			}
+			end += next
+			if m := run(line, end, '`'); m != n {
+				end += m
+				continue
+			}
+			c.out.WriteString(line[i : end+n])
+			return end + n
+		}
+		c.out.WriteString(line[i : i+n])
+		return i + n
+	case ch == '{':
+		c.open(modeExpr)
+		c.buf.WriteByte(ch)
+		c.braces = 1
+		return i + 1
+	case ch == '<' && strings.HasPrefix(line[i:], "<!--"):
+		// HTML comments aren't valid MDX, but they were supported by MDX 1.
+		end := strings.Index(line[i:], "-->")
+		if end < 0 {
+			c.out.WriteString(line[i:])
+			return len(line)
+		}
+		c.out.WriteString(line[i : i+end+3])
+		return i + end + 3
+	case ch == '<' && i+1 < len(line) && isTagStart(line[i+1]):
+		c.open(modeTag)
+		c.buf.WriteByte(ch)
+		return i + 1
+	}
+	c.out.WriteByte(ch)
+	return i + 1
+}
+
+// step updates the state of the current tag or expression after `line[*i]`.
+func (c *converter) step(line string, i *int) {
+	ch := line[*i]
+	switch {
+	case c.comment:
+		if ch == '*' && *i+1 < len(line) && line[*i+1] == '/' {
+			c.buf.WriteByte('/')
+			*i++
+			c.comment = false
+		}
+	case c.quote != 0:
+		if ch == '\\' && *i+1 < len(line) && c.mode == modeExpr {
+			c.buf.WriteByte(line[*i+1])
+			*i++
+		} else if ch == c.quote {
+			c.quote = 0
+		}
+	case ch == '/' && c.braces > 0 && *i+1 < len(line) && line[*i+1] == '*':
+		c.buf.WriteByte('*')
+		*i++
+		c.comment = true
+	case ch == '"' || ch == '\'' || (ch == '`' && c.braces > 0):
+		c.quote = ch
+	case ch == '{':
+		c.braces++
+	case ch == '}' && c.braces > 0:
+		c.braces--
+		if c.braces == 0 && c.mode == modeExpr {
+			c.closeExpr()
+		}
+	case ch == '>' && c.braces == 0 && c.mode == modeTag:
+		c.closeTag()
+	}
+}
+
+func (c *converter) open(mode int) {
+	c.mode = mode
+	c.start = c.line
+	c.buf.Reset()
+}
+
+func (c *converter) closeExpr() {
+	c.mask(c.from, c.from+c.buf.Len())
+	if m := reComment.FindStringSubmatch(c.buf.String()); m != nil {
+		c.out.WriteString("<!-- " + m[1] + " -->")
+	}
+	c.mode = modeText
+}
+
+func (c *converter) closeTag() {
+	tag := c.buf.String()
+	c.mode = modeText
+
+	c.mask(c.from, c.from+len(tag))
+	if strings.HasPrefix(tag, "</") {
+		if c.depth > 0 {
+			c.depth--
+		}
+		return
+	} else if !strings.HasSuffix(tag, "/>") {
+		c.depth++
+	}
+
+	component := ""
+	if m := reName.FindStringSubmatch(tag); m != nil {
+		component = m[1]
+	}
+
+	for _, m := range reAttr.FindAllStringSubmatchIndex(maskExprs(tag), -1) {
+		name := tag[m[2]:m[3]]
+		if !c.wants(component, name) {
+			continue
+		}
+
+		start, end := m[4], m[5]
+		if start < 0 {
+			start, end = m[6], m[7]
+		}
+
+		c.props = append(c.props, Prop{
+			Component: component,
+			Name:      name,
+			Value:     tag[start:end],
+			Line:      c.start + strings.Count(tag[:start], "\n"),
+		})
+		for i := c.from + start; i < c.from+end; i++ {
+			c.hidden[i] = false
+		}
+	}
+}
+
+func (c *converter) wants(component, name string) bool {
+	for _, n := range c.names {
+		if n == name || n == component+"."+name {
+			return true
+		}
+	}
+	return false
+}
+
+// maskExprs replaces the content of any expressions in `tag` with spaces (so
+// that we don't mistake, e.g., `{{a: "b"}}` for an attribute).
+func maskExprs(tag string) string {
+	masked := []byte(tag)
+
+	depth, quote := 0, byte(0)
+	for i := 0; i < len(masked); i++ {
+		ch := masked[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '{':
+			depth++
+		case ch == '}' && depth > 0:
+			depth--
+			if depth == 0 {
+				masked[i] = ' '
+			}
+		}
+		if depth > 0 && ch != '\n' {
+			masked[i] = ' '
+		}
+	}
+
+	return string(masked)
+}
+
+func run(s string, i int, ch byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == ch {
+		n++
+	}
+	return n
+}
+
+func isTagStart(ch byte) bool {
+	return ch == '/' || ch == '>' || ch == '_' || ch == '$' ||
+		(ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
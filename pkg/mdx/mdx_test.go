@@ -0,0 +1,80 @@
+package mdx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	doc := `import {Card, Note} from './components'
+export const meta = {
+  title: 'TODO'
+}
+
+# Hello, {props.name}!
+
+<Note title="Read this first" kind='info'>
+    Indented *Markdown* content.
+</Note>
+
+{/* vale off */}
+
+Use ` + "`<Card>` and `{x}`" + ` in code.
+
+<Card
+  title="Get started"
+  href={"/start"}
+  style={{color: "red"}} />
+
+` + "```js\nexport const x = {a: 1}\n```\n"
+
+	converted := Convert(doc, []string{"title", "Card.href"})
+	out := converted.Markdown
+
+	for _, s := range []string{
+		"# Hello, !",
+		"\nIndented *Markdown* content.\n",
+		"<!-- vale off -->",
+		"Use `<Card>` and `{x}` in code.",
+		"```js\nexport const x = {a: 1}\n```\n"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in:\n%s", s, out)
+		}
+	}
+
+	for _, s := range []string{"import", "TODO", "<Note", "</Note>", "kind", "color"} {
+		if strings.Contains(out[:strings.Index(out, "```js")], s) {
+			t.Errorf("unexpected %q in:\n%s", s, out)
+		}
+	}
+
+	if len(strings.Split(out, "\n")) != len(strings.Split(doc, "\n")) {
+		t.Errorf("expected the number of lines to be preserved:\n%s", out)
+	}
+
+	expected := []Prop{
+		{Component: "Note", Name: "title", Value: "Read this first", Line: 8},
+		{Component: "Card", Name: "title", Value: "Get started", Line: 17},
+	}
+	if !reflect.DeepEqual(converted.Props, expected) {
+		t.Errorf("expected %v, got %v", expected, converted.Props)
+	}
+
+	masked := strings.Split(converted.Masked, "\n")
+	for i, line := range strings.Split(doc, "\n") {
+		if len(masked[i]) != len(line) {
+			t.Errorf("expected %q to have the length of %q", masked[i], line)
+		}
+	}
+
+	for _, s := range []string{"# Hello, ************!", "Read this first", "Get started"} {
+		if !strings.Contains(converted.Masked, s) {
+			t.Errorf("expected %q in:\n%s", s, converted.Masked)
+		}
+	}
+
+	if strings.Contains(converted.Masked, "Note") || strings.Contains(converted.Masked, "TODO") {
+		t.Errorf("unexpected unmasked syntax in:\n%s", converted.Masked)
+	}
+}
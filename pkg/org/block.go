@@ -0,0 +1,357 @@
+package org
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	reHeadline  = regexp.MustCompile(`^(\*+)\s+(.*)$`)
+	rePriority  = regexp.MustCompile(`^\[#\w\]\s*`)
+	reTags      = regexp.MustCompile(`\s+:[\w@#%:]+:\s*$`)
+	reBegin     = regexp.MustCompile(`(?i)^\s*#\+begin_(\w+)`)
+	reKeyword   = regexp.MustCompile(`^\s*#\+(\w+):\s*(.*)$`)
+	reDirective = regexp.MustCompile(`^\s*#\+`)
+	reComment   = regexp.MustCompile(`^\s*#(?:\s|$)`)
+	reDrawer    = regexp.MustCompile(`^\s*:[\w-]+:\s*$`)
+	reEnd       = regexp.MustCompile(`(?i)^\s*:end:\s*$`)
+	rePlanning  = regexp.MustCompile(`^\s*(?:SCHEDULED|DEADLINE|CLOSED):`)
+	reFixed     = regexp.MustCompile(`^\s*:(?:\s|$)`)
+	reTable     = regexp.MustCompile(`^\s*\|`)
+	reRule      = regexp.MustCompile(`^\s*-{5,}\s*$`)
+	reItem      = regexp.MustCompile(`^(\s*)([-+*]|\d+[.)]|[A-Za-z][.)])(?:\s+|$)`)
+	reCheckbox  = regexp.MustCompile(`^(?:\[@\w+\]\s*)?(?:\[[ X-]\]\s*)?`)
+	reFootnote  = regexp.MustCompile(`^\[fn:[^\]]+\]\s*`)
+	reSep       = regexp.MustCompile(`^\s*\|[-+]+\|?\s*$`)
+)
+
+// A Document is a converted Org-mode document.
+type Document struct {
+	HTML   string // the HTML content
+	Masked string // the source, with everything but its content masked
+}
+
+// line is the location of a line (without its newline) in the source.
+type line struct {
+	start, end int
+}
+
+type parser struct {
+	src    string
+	lines  []line
+	hidden []bool // the source bytes that aren't part of the output
+	todo   []string
+	out    strings.Builder
+}
+
+// Convert converts the Org-mode document `src` into HTML.
+//
+// Since the HTML doesn't include markup, comments, or drawers, we also
+// return a masked copy of `src` (with the same lines and the same number of
+// characters on each line) in which they've been replaced by asterisks. This
+// allows us to avoid matching their text when locating alerts.
+func Convert(src string) Document {
+	p := parser{
+		src:    src,
+		hidden: make([]bool, len(src)),
+		todo:   []string{"TODO", "DONE"},
+	}
+
+	for i := range p.hidden {
+		p.hidden[i] = true
+	}
+
+	start := 0
+	for i, ch := range src {
+		if ch == '\n' {
+			p.lines = append(p.lines, line{start, i})
+			start = i + 1
+		}
+	}
+	if start < len(src) {
+		p.lines = append(p.lines, line{start, len(src)})
+	}
+
+	for _, l := range p.lines {
+		if m := reKeyword.FindStringSubmatch(p.line(l)); m != nil {
+			switch strings.ToUpper(m[1]) {
+			case "TODO", "SEQ_TODO", "TYP_TODO":
+				for _, kw := range strings.Fields(m[2]) {
+					if kw != "|" {
+						p.todo = append(p.todo, strings.SplitN(kw, "(", 2)[0])
+					}
+				}
+			}
+		}
+	}
+
+	p.blocks(0, len(p.lines))
+	return Document{HTML: p.out.String(), Masked: p.masked()}
+}
+
+// blocks converts the lines in the range [from, to).
+func (p *parser) blocks(from, to int) {
+	for i := from; i < to; {
+		t := p.line(p.lines[i])
+		switch {
+		case strings.TrimSpace(t) == "":
+			i++
+		case reHeadline.MatchString(t):
+			i = p.headline(i, to)
+		case reBegin.MatchString(t):
+			i = p.block(i, to)
+		case reKeyword.MatchString(t):
+			i = p.keyword(i)
+		case reDirective.MatchString(t):
+			i++
+		case reComment.MatchString(t):
+			comment := strings.TrimSpace(strings.TrimSpace(t)[1:])
+			if strings.HasPrefix(comment, "vale ") {
+				p.out.WriteString("<!-- " + comment + " -->\n")
+			}
+			i++
+		case reDrawer.MatchString(t):
+			i = p.drawer(i, to)
+		case rePlanning.MatchString(t), reRule.MatchString(t):
+			i++
+		case reFixed.MatchString(t):
+			i = p.fixed(i, to)
+		case reTable.MatchString(t):
+			i = p.table(i, to)
+		case p.item(t) != nil:
+			i = p.list(i, to)
+		default:
+			i = p.paragraph(i, to)
+		}
+	}
+}
+
+// starts reports whether `t` starts an element other than a paragraph.
+func (p *parser) starts(t string) bool {
+	return strings.TrimSpace(t) == "" || reHeadline.MatchString(t) ||
+		reBegin.MatchString(t) || reDirective.MatchString(t) ||
+		reComment.MatchString(t) || reDrawer.MatchString(t) ||
+		rePlanning.MatchString(t) || reFixed.MatchString(t) ||
+		reTable.MatchString(t) || reRule.MatchString(t) || p.item(t) != nil
+}
+
+func (p *parser) headline(i, to int) int {
+	l := p.lines[i]
+	m := reHeadline.FindStringSubmatchIndex(p.line(l))
+
+	level := m[3] - m[2]
+	start, end := l.start+m[4], l.end
+
+	title := p.src[start:end]
+	for _, kw := range p.todo {
+		if strings.HasPrefix(title, kw+" ") || title == kw {
+			start += len(kw)
+			break
+		}
+	}
+	title = p.src[start:end]
+	start += len(title) - len(strings.TrimLeft(title, " \t"))
+
+	if loc := rePriority.FindStringIndex(p.src[start:end]); loc != nil {
+		start += loc[1]
+	}
+	if loc := reTags.FindStringIndex(p.src[start:end]); loc != nil {
+		end = start + loc[0]
+	}
+
+	if strings.HasPrefix(p.src[start:end], "COMMENT") {
+		// A commented subtree, which isn't exported.
+		for i++; i < to; i++ {
+			if m := reHeadline.FindStringSubmatch(p.line(p.lines[i])); m != nil && len(m[1]) <= level {
+				break
+			}
+		}
+		return i
+	}
+
+	if level > 6 {
+		level = 6
+	}
+	tag := "h" + strconv.Itoa(level)
+
+	p.out.WriteString("<" + tag + ">")
+	p.inline(start, end)
+	p.out.WriteString("</" + tag + ">\n")
+
+	return i + 1
+}
+
+// block converts a `#+BEGIN_<name>` block, returning the index of the line
+// after its `#+END_<name>`.
+func (p *parser) block(i, to int) int {
+	name := strings.ToLower(reBegin.FindStringSubmatch(p.line(p.lines[i]))[1])
+
+	end := i + 1
+	for end < to && !strings.EqualFold(strings.TrimSpace(p.line(p.lines[end])), "#+end_"+name) {
+		end++
+	}
+
+	switch name {
+	case "src", "example":
+		p.out.WriteString("<pre>")
+		if end > i+1 {
+			p.text(p.lines[i+1].start, p.lines[end-1].end)
+		}
+		p.out.WriteString("</pre>\n")
+	case "export", "comment":
+	case "quote", "verse":
+		p.out.WriteString("<blockquote>\n")
+		p.blocks(i+1, end)
+		p.out.WriteString("</blockquote>\n")
+	default:
+		p.blocks(i+1, end)
+	}
+
+	return end + 1
+}
+
+func (p *parser) keyword(i int) int {
+	l := p.lines[i]
+	m := reKeyword.FindStringSubmatchIndex(p.line(l))
+	if strings.EqualFold(p.line(l)[m[2]:m[3]], "title") && m[5] > m[4] {
+		p.out.WriteString("<h1>")
+		p.inline(l.start+m[4], l.start+m[5])
+		p.out.WriteString("</h1>\n")
+	}
+	return i + 1
+}
+
+// drawer skips a drawer (e.g., `:PROPERTIES:`).
+func (p *parser) drawer(i, to int) int {
+	for end := i + 1; end < to; end++ {
+		if reEnd.MatchString(p.line(p.lines[end])) {
+			return end + 1
+		}
+	}
+	return i + 1
+}
+
+// fixed converts fixed-width lines (i.e., those starting with ": ").
+func (p *parser) fixed(i, to int) int {
+	end := i
+	for end < to && reFixed.MatchString(p.line(p.lines[end])) {
+		end++
+	}
+
+	p.out.WriteString("<pre>")
+	p.text(p.lines[i].start, p.lines[end-1].end)
+	p.out.WriteString("</pre>\n")
+
+	return end
+}
+
+func (p *parser) paragraph(i, to int) int {
+	l := p.lines[i]
+
+	start := l.start
+	if loc := reFootnote.FindStringIndex(p.line(l)); loc != nil {
+		start += loc[1]
+	}
+
+	end := i + 1
+	for end < to && !p.starts(p.line(p.lines[end])) {
+		end++
+	}
+
+	p.out.WriteString("<p>")
+	p.inline(start, p.lines[end-1].end)
+	p.out.WriteString("</p>\n")
+
+	return end
+}
+
+func (p *parser) table(i, to int) int {
+	end := i
+	for end < to && reTable.MatchString(p.line(p.lines[end])) {
+		end++
+	}
+
+	// Rows above the first separator are header rows, provided there are
+	// rows below it.
+	header := 0
+	for j := i; j < end; j++ {
+		if reSep.MatchString(p.line(p.lines[j])) {
+			if j > i && j < end-1 {
+				header = j
+			}
+			break
+		}
+	}
+
+	p.out.WriteString("<table>\n")
+	for j := i; j < end; j++ {
+		l := p.lines[j]
+		if reSep.MatchString(p.line(l)) {
+			continue
+		}
+
+		tag := "td"
+		if j < header {
+			tag = "th"
+		}
+
+		p.out.WriteString("<tr>")
+		p.cells(l, tag)
+		p.out.WriteString("</tr>\n")
+	}
+	p.out.WriteString("</table>\n")
+
+	return end
+}
+
+func (p *parser) cells(l line, tag string) {
+	t := p.line(l)
+
+	start := l.start + strings.IndexByte(t, '|') + 1
+	for start <= l.end {
+		end := strings.IndexByte(p.src[start:l.end], '|')
+		if end < 0 {
+			end = l.end
+		} else {
+			end += start
+		}
+
+		if end == l.end && strings.TrimSpace(p.src[start:end]) == "" {
+			break
+		}
+
+		p.out.WriteString("<" + tag + ">")
+		p.inline(start, end)
+		p.out.WriteString("</" + tag + ">")
+
+		start = end + 1
+	}
+}
+
+// text writes the source text between `start` and `end`.
+func (p *parser) text(start, end int) {
+	p.out.WriteString(html.EscapeString(p.src[start:end]))
+	for i := start; i < end; i++ {
+		p.hidden[i] = false
+	}
+}
+
+func (p *parser) line(l line) string {
+	return p.src[l.start:l.end]
+}
+
+// masked returns the source with its hidden characters replaced by
+// asterisks.
+func (p *parser) masked() string {
+	var b strings.Builder
+	for i, r := range p.src {
+		if p.hidden[i] && r != '\n' && r != '\r' {
+			b.WriteByte('*')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,2 @@
+// Package org implements a pure-Go converter from Org-mode to HTML.
+package org
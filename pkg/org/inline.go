@@ -0,0 +1,150 @@
+package org
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	reTimestamp = regexp.MustCompile(`^[<\[]\d{4}-\d{2}-\d{2}[^>\]\n]*[>\]](?:--[<\[][^>\]\n]*[>\]])?`)
+	reInlineSrc = regexp.MustCompile(`^src_\w+(?:\[[^\]\n]*\])?\{([^}\n]*)\}`)
+)
+
+// markup maps emphasis markers to their HTML tags.
+//
+// NOTE: Underlined text is kept as-is (there's no suitable inline tag), and
+// `=verbatim=` and `~code~` are handled separately.
+var markup = map[byte]string{
+	'*': "strong",
+	'/': "em",
+	'+': "del",
+	'_': "",
+}
+
+// inline converts the inline markup in the source between `start` and `end`.
+func (p *parser) inline(start, end int) {
+	last := start
+	for i := start; i < end; {
+		skip := 0
+		switch ch := p.src[i]; {
+		case strings.HasPrefix(p.src[i:end], "[["):
+			if j := strings.Index(p.src[i:end], "]]"); j > 0 {
+				p.text(last, i)
+				i = p.link(i, i+j)
+				last = i
+				continue
+			}
+		case strings.HasPrefix(p.src[i:end], "[fn:"):
+			if j := strings.IndexByte(p.src[i:end], ']'); j > 0 {
+				skip = j + 1
+			}
+		case strings.HasPrefix(p.src[i:end], "{{{"):
+			if j := strings.Index(p.src[i+3:end], "}}}"); j >= 0 {
+				skip = j + 6
+			}
+		case strings.HasPrefix(p.src[i:end], "@@"):
+			if j := strings.Index(p.src[i+2:end], "@@"); j >= 0 {
+				skip = j + 4
+			}
+		case ch == '<' || ch == '[':
+			if loc := reTimestamp.FindStringIndex(p.src[i:end]); loc != nil {
+				skip = loc[1]
+			}
+		case ch == 's' && (i == start || !isWord(p.src[i-1])):
+			if m := reInlineSrc.FindStringSubmatchIndex(p.src[i:end]); m != nil {
+				p.text(last, i)
+				p.code(i+m[2], i+m[3])
+				i += m[1]
+				last = i
+				continue
+			}
+		case strings.IndexByte("*/+_=~", ch) >= 0 && p.opens(i, start, end):
+			if j := p.closes(i, end); j > 0 {
+				p.text(last, i)
+				if ch == '=' || ch == '~' {
+					p.code(i+1, j)
+				} else {
+					p.emphasis(markup[ch], i+1, j)
+				}
+				i = j + 1
+				last = i
+				continue
+			}
+		}
+
+		if skip > 0 {
+			p.text(last, i)
+			i += skip
+			last = i
+		} else {
+			i++
+		}
+	}
+	p.text(last, end)
+}
+
+// link converts a `[[target][description]]` link (ending at `stop`),
+// returning the offset after it.
+func (p *parser) link(i, stop int) int {
+	target := p.src[i+2 : stop]
+	desc := strings.Index(target, "][")
+	if desc >= 0 {
+		target = target[:desc]
+	}
+
+	p.out.WriteString(`<a href="` + html.EscapeString(target) + `">`)
+	if desc >= 0 {
+		p.inline(i+2+desc+2, stop)
+	}
+	p.out.WriteString("</a>")
+
+	return stop + 2
+}
+
+func (p *parser) code(start, end int) {
+	p.out.WriteString("<code>")
+	p.text(start, end)
+	p.out.WriteString("</code>")
+}
+
+func (p *parser) emphasis(tag string, start, end int) {
+	if tag != "" {
+		p.out.WriteString("<" + tag + ">")
+	}
+	p.inline(start, end)
+	if tag != "" {
+		p.out.WriteString("</" + tag + ">")
+	}
+}
+
+// opens reports whether the marker at `i` can start emphasis.
+func (p *parser) opens(i, start, end int) bool {
+	if i > start && !strings.ContainsRune(" \t\n-('\"{", rune(p.src[i-1])) {
+		return false
+	}
+	return i+1 < end && !isSpace(p.src[i+1])
+}
+
+// closes finds the marker that closes the emphasis started at `i`, returning
+// -1 if there isn't one.
+func (p *parser) closes(i, end int) int {
+	ch := p.src[i]
+	for j := i + 2; j < end; j++ {
+		if p.src[j] != ch || isSpace(p.src[j-1]) {
+			continue
+		} else if j+1 == end || strings.ContainsRune(" \t\n-.,:!?;'\")}[", rune(p.src[j+1])) {
+			return j
+		}
+	}
+	return -1
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
+
+func isWord(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') ||
+		(ch >= '0' && ch <= '9')
+}
@@ -0,0 +1,127 @@
+package org
+
+import (
+	"regexp"
+	"strings"
+)
+
+var reTerm = regexp.MustCompile(`^(.*?)\s+::(?:\s+|$)`)
+
+// item is a plain-list item.
+type item struct {
+	indent  int
+	ordered bool
+	body    int // the offset (in its line) of the item's text
+}
+
+// item parses `t` as a list item, returning nil if it isn't one.
+func (p *parser) item(t string) *item {
+	m := reItem.FindStringSubmatchIndex(t)
+	if m == nil {
+		return nil
+	}
+
+	indent := m[3] - m[2]
+	bullet := t[m[4]:m[5]]
+	if bullet == "*" && indent == 0 {
+		// This is a headline.
+		return nil
+	}
+
+	body := m[1]
+	if loc := reCheckbox.FindStringIndex(t[body:]); loc != nil {
+		body += loc[1]
+	}
+
+	return &item{
+		indent:  indent,
+		ordered: !strings.ContainsAny(bullet, "-+*"),
+		body:    body,
+	}
+}
+
+// list converts the plain list starting on line `i`, returning the index of
+// the line after it.
+func (p *parser) list(i, to int) int {
+	first := p.item(p.line(p.lines[i]))
+
+	tag := "ul"
+	if first.ordered {
+		tag = "ol"
+	}
+
+	p.out.WriteString("<" + tag + ">\n")
+	for i < to {
+		it := p.item(p.line(p.lines[i]))
+		if it == nil || it.indent != first.indent || it.ordered != first.ordered {
+			break
+		}
+
+		// An item ends at the first line that isn't indented past its
+		// bullet (or at two consecutive blank lines).
+		end, blanks := i+1, 0
+		for ; end < to; end++ {
+			t := p.line(p.lines[end])
+			if strings.TrimSpace(t) == "" {
+				blanks++
+				if blanks > 1 {
+					break
+				}
+				continue
+			} else if indentation(t) <= first.indent {
+				break
+			}
+			blanks = 0
+		}
+
+		next := end
+		for next > i+1 && strings.TrimSpace(p.line(p.lines[next-1])) == "" {
+			next--
+		}
+
+		p.out.WriteString("<li>")
+		rest := p.itemText(i, next, it)
+		p.out.WriteString("\n")
+		p.blocks(rest, next)
+		p.out.WriteString("</li>\n")
+
+		i = end
+		if end < to && strings.TrimSpace(p.line(p.lines[end])) == "" {
+			break
+		}
+	}
+	p.out.WriteString("</" + tag + ">\n")
+
+	return i
+}
+
+// itemText converts the first paragraph of an item, returning the index of
+// the line after it.
+func (p *parser) itemText(i, to int, it *item) int {
+	l := p.lines[i]
+	start := l.start + it.body
+
+	end := i + 1
+	for end < to && !p.starts(p.line(p.lines[end])) {
+		end++
+	}
+	stop := p.lines[end-1].end
+
+	if start >= stop {
+		return end
+	}
+
+	if m := reTerm.FindStringSubmatchIndex(p.src[start:l.end]); m != nil {
+		p.out.WriteString("<strong>")
+		p.inline(start, start+m[3])
+		p.out.WriteString("</strong> ")
+		start += m[1]
+	}
+
+	p.inline(start, stop)
+	return end
+}
+
+func indentation(t string) int {
+	return len(t) - len(strings.TrimLeft(t, " \t"))
+}
@@ -0,0 +1,57 @@
+package org
+
+import (
+	"strings"
+	"testing"
+)
+
+var htmlTests = []struct {
+	org      string
+	contains []string
+	excludes []string
+}{
+	{"#+TITLE: Notes\n* TODO [#A] Setup :ops:\n** Details\nText.", []string{
+		"<h1>Notes</h1>", "<h1>Setup</h1>", "<h2>Details</h2>", "<p>Text.</p>"}, []string{"ops", "TODO"}},
+	{"#+TODO: WAIT | DONE\n* WAIT Review", []string{"<h1>Review</h1>"}, []string{"WAIT"}},
+	{"* Heading\n  :PROPERTIES:\n  :ID: 123\n  :END:\n  SCHEDULED: <2024-01-01 Mon>\nBody.", []string{
+		"<h1>Heading</h1>\n<p>Body.</p>"}, []string{"123", "SCHEDULED"}},
+	{"Use *this*, /that/, +not+, =x=, ~y~ and src_go{z()}; 2*3*4.", []string{
+		"<strong>this</strong>", "<em>that</em>", "<del>not</del>", "<code>x</code>",
+		"<code>y</code>", "<code>z()</code>", "2*3*4."}, nil},
+	{"See [[https://a.com][the site]], [[file:b.org]] and a note.[fn:1]", []string{
+		`<a href="https://a.com">the site</a>`, `<a href="file:b.org"></a>`, "a note.</p>"}, []string{"fn:1"}},
+	{"- One\n  more\n  - Two\n- [X] Term :: Three\n\n1. Four", []string{
+		"<ul>\n<li>One\n  more\n<ul>\n<li>Two\n</li>\n</ul>\n</li>",
+		"<li><strong>Term</strong> Three\n</li>", "<ol>\n<li>Four"}, []string{"[X]"}},
+	{"| A | B |\n|---+---|\n| 1 | 2 |", []string{
+		"<tr><th> A </th><th> B </th></tr>", "<tr><td> 1 </td><td> 2 </td></tr>"}, nil},
+	{"#+BEGIN_SRC go\nx := 1\n#+END_SRC\n#+begin_quote\nA quote.\n#+end_quote\n: fixed", []string{
+		"<pre>x := 1</pre>", "<blockquote>\n<p>A quote.</p>\n</blockquote>", "<pre>: fixed</pre>"}, nil},
+	{"# A comment.\n# vale off\n#+BEGIN_COMMENT\nHidden.\n#+END_COMMENT\n* COMMENT Draft\nHidden.\n* Done", []string{
+		"<!-- vale off -->\n<h1>Done</h1>"}, []string{"comment", "Hidden", "Draft"}},
+}
+
+func TestConvert(t *testing.T) {
+	for _, tt := range htmlTests {
+		out := Convert(tt.org).HTML
+		for _, s := range tt.contains {
+			if !strings.Contains(out, s) {
+				t.Errorf("%q: expected %q in:\n%s", tt.org, s, out)
+			}
+		}
+		for _, s := range tt.excludes {
+			if strings.Contains(out, s) {
+				t.Errorf("%q: unexpected %q in:\n%s", tt.org, s, out)
+			}
+		}
+	}
+}
+
+func TestMasked(t *testing.T) {
+	src := "* TODO Intro :tag:\n# A note.\nSome *bold* text.\n"
+
+	masked := Convert(src).Masked
+	if masked != "*******Intro******\n*********\nSome *bold* text.\n" {
+		t.Errorf("unexpected masked source: %q", masked)
+	}
+}
@@ -0,0 +1,429 @@
+package rst
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	reBullet       = regexp.MustCompile(`^[-*+•‣⁃](?: +|$)`)
+	reEnumerated   = regexp.MustCompile(`^(?:\((?:\d+|#|[A-Za-z]|[ivxlcdm]+|[IVXLCDM]+)\)|(?:\d+|#|[A-Za-z]|[ivxlcdm]+|[IVXLCDM]+)[.)])(?: +|$)`)
+	reField        = regexp.MustCompile(`^:((?:[^:\\]|\\.)+):(?: +|$)`)
+	reDirective    = regexp.MustCompile(`^\.\. +(\w[\w:.+-]*?)::(?: +(.*))?$`)
+	reFootnote     = regexp.MustCompile(`^\.\. +\[([^\]]+)\](?: +(.*))?$`)
+	reTarget       = regexp.MustCompile(`^\.\. +(?:_|\|[^|]+\|)`)
+	reOption       = regexp.MustCompile(`^:[\w-]+:(?: +.*)?$`)
+	reLineBlock    = regexp.MustCompile(`^\|(?: +|$)`)
+	reGridBorder   = regexp.MustCompile(`^\+(?:[-=]+\+)+$`)
+	reSimpleBorder = regexp.MustCompile(`^=+(?: +=+)+$`)
+)
+
+// parser converts reST body elements into HTML.
+type parser struct {
+	out    bytes.Buffer
+	styles []string // section title adornments, in the order they were seen
+}
+
+// ToHTML converts the reStructuredText `src` into HTML.
+//
+// The opening tag of each top-level element records the element's (1-based)
+// line in `src` as a `data-line` attribute.
+func ToHTML(src string) string {
+	p := parser{}
+
+	lines := splitLines(src)
+	for i := 0; i < len(lines); {
+		if isBlank(lines[i]) {
+			i++
+			continue
+		}
+		start := p.out.Len()
+		end := p.block(lines, i)
+		p.mark(start, i+1)
+		i = end
+	}
+
+	return p.out.String()
+}
+
+// mark adds a `data-line` attribute to the tag (if any) that was written at
+// `start`.
+func (p *parser) mark(start, line int) {
+	out := p.out.Bytes()[start:]
+	if len(out) < 2 || out[0] != '<' || !unicode.IsLetter(rune(out[1])) {
+		return
+	}
+
+	end := bytes.IndexAny(out, " />")
+	tail := append([]byte(fmt.Sprintf(` data-line="%d"`, line)), out[end:]...)
+
+	p.out.Truncate(start + end)
+	p.out.Write(tail)
+}
+
+// body converts a sequence of body elements, all of which are expected to
+// start at the same (zero) indentation.
+func (p *parser) body(lines []string) {
+	for i := 0; i < len(lines); {
+		if isBlank(lines[i]) {
+			i++
+			continue
+		}
+		i = p.block(lines, i)
+	}
+}
+
+// block converts the element starting at `lines[i]`, returning the index of
+// the first line after it.
+func (p *parser) block(lines []string, i int) int {
+	line := lines[i]
+	next := ""
+	if i+1 < len(lines) {
+		next = lines[i+1]
+	}
+
+	switch {
+	case indentOf(line) > 0:
+		return p.blockQuote(lines, i)
+	case line == ".." || strings.HasPrefix(line, ".. "):
+		return p.explicit(lines, i)
+	case strings.HasPrefix(line, "__ "):
+		_, end := indented(lines, i+1)
+		return end
+	case p.isTitle(lines, i):
+		return p.title(lines, i)
+	case isAdornment(line) && utf8.RuneCountInString(line) >= 4 && isBlank(next):
+		p.out.WriteString("<hr/>\n")
+		return i + 1
+	case reGridBorder.MatchString(line):
+		return p.gridTable(lines, i)
+	case reSimpleBorder.MatchString(line):
+		return p.simpleTable(lines, i)
+	case reBullet.MatchString(line):
+		return p.list(lines, i, reBullet, "ul")
+	case reEnumerated.MatchString(line) && isEnumerated(lines, i):
+		return p.list(lines, i, reEnumerated, "ol")
+	case reField.MatchString(line):
+		return p.fieldList(lines, i)
+	case strings.HasPrefix(line, ">>>"):
+		end := i
+		for end < len(lines) && !isBlank(lines[end]) {
+			end++
+		}
+		p.literal(lines[i:end])
+		return end
+	case reLineBlock.MatchString(line):
+		return p.lineBlock(lines, i)
+	case indentOf(next) > 0 && !isBlank(next):
+		return p.definitionList(lines, i)
+	}
+
+	return p.paragraph(lines, i)
+}
+
+func (p *parser) paragraph(lines []string, i int) int {
+	end := i
+	for end < len(lines) && !isBlank(lines[end]) {
+		end++
+	}
+
+	text := strings.Join(lines[i:end], "\n")
+
+	// A paragraph ending in "::" introduces a literal block.
+	expectLiteral := strings.HasSuffix(text, "::")
+	if expectLiteral {
+		switch {
+		case strings.TrimSpace(text) == "::":
+			text = ""
+		case strings.HasSuffix(text, " ::") || strings.HasSuffix(text, "\n::"):
+			text = strings.TrimRight(text[:len(text)-2], " \n")
+		default:
+			text = text[:len(text)-1]
+		}
+	}
+
+	if text != "" {
+		p.out.WriteString("<p>" + inline(text) + "</p>\n")
+	}
+
+	if expectLiteral {
+		j := end
+		for j < len(lines) && isBlank(lines[j]) {
+			j++
+		}
+		if j < len(lines) && indentOf(lines[j]) > 0 {
+			block, k := indented(lines, j)
+			p.literal(block)
+			return k
+		}
+	}
+
+	return end
+}
+
+func (p *parser) literal(lines []string) {
+	p.out.WriteString("<pre>")
+	p.out.WriteString(html.EscapeString(strings.Join(lines, "\n")))
+	p.out.WriteString("</pre>\n")
+}
+
+func (p *parser) blockQuote(lines []string, i int) int {
+	block, end := indented(lines, i)
+	p.out.WriteString("<blockquote>\n")
+	p.body(block)
+	p.out.WriteString("</blockquote>\n")
+	return end
+}
+
+// isTitle reports whether `lines[i]` starts a section title, either with an
+// underline or with an overline and underline.
+func (p *parser) isTitle(lines []string, i int) bool {
+	if i+1 >= len(lines) {
+		return false
+	}
+	line, next := lines[i], lines[i+1]
+
+	if isAdornment(line) {
+		return i+2 < len(lines) && !isBlank(next) && lines[i+2] == line
+	}
+
+	width := utf8.RuneCountInString(strings.TrimSpace(line))
+	if width > 4 {
+		width = 4
+	}
+	return isAdornment(next) && utf8.RuneCountInString(next) >= width
+}
+
+func (p *parser) title(lines []string, i int) int {
+	text, style, end := lines[i], lines[i+1][:1], i+2
+	if isAdornment(lines[i]) {
+		text, style, end = lines[i+1], "o"+lines[i][:1], i+3
+	}
+
+	level := 0
+	for level < len(p.styles) && p.styles[level] != style {
+		level++
+	}
+	if level == len(p.styles) {
+		p.styles = append(p.styles, style)
+	}
+	if level > 5 {
+		level = 5
+	}
+
+	p.out.WriteString(fmt.Sprintf("<h%d>%s</h%d>\n",
+		level+1, inline(strings.TrimSpace(text)), level+1))
+
+	return end
+}
+
+// list converts a bullet (`ul`) or enumerated (`ol`) list.
+func (p *parser) list(lines []string, i int, marker *regexp.Regexp, tag string) int {
+	p.out.WriteString("<" + tag + ">\n")
+	for i < len(lines) {
+		m := marker.FindString(lines[i])
+		if m == "" {
+			break
+		}
+
+		block, end := indented(lines, i+1)
+		p.out.WriteString("<li>")
+		p.body(append([]string{lines[i][len(m):]}, block...))
+		p.out.WriteString("</li>\n")
+
+		i = end
+	}
+	p.out.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// fieldList converts a field list, which docutils renders as a two-column
+// table.
+func (p *parser) fieldList(lines []string, i int) int {
+	p.out.WriteString("<table class=\"field-list\">\n")
+	for i < len(lines) {
+		m := reField.FindStringSubmatch(lines[i])
+		if m == nil {
+			break
+		}
+
+		block, end := indented(lines, i+1)
+		p.out.WriteString("<tr><th>" + inline(m[1]) + "</th><td>")
+		p.body(append([]string{lines[i][len(m[0]):]}, block...))
+		p.out.WriteString("</td></tr>\n")
+
+		i = end
+	}
+	p.out.WriteString("</table>\n")
+	return i
+}
+
+func (p *parser) definitionList(lines []string, i int) int {
+	p.out.WriteString("<dl>\n")
+	for i+1 < len(lines) && indentOf(lines[i]) == 0 && !isBlank(lines[i]) &&
+		indentOf(lines[i+1]) > 0 && !isBlank(lines[i+1]) {
+		term := lines[i]
+		if idx := strings.Index(term, " : "); idx > 0 {
+			// Drop any classifiers.
+			term = term[:idx]
+		}
+
+		block, end := indented(lines, i+1)
+		p.out.WriteString("<dt>" + inline(term) + "</dt>\n<dd>")
+		p.body(block)
+		p.out.WriteString("</dd>\n")
+
+		i = end
+	}
+	p.out.WriteString("</dl>\n")
+	return i
+}
+
+func (p *parser) lineBlock(lines []string, i int) int {
+	text := []string{}
+	for i < len(lines) && !isBlank(lines[i]) {
+		line := lines[i]
+		if m := reLineBlock.FindString(line); m != "" {
+			line = line[len(m):]
+		}
+		text = append(text, strings.TrimSpace(line))
+		i++
+	}
+	p.out.WriteString("<p>" + inline(strings.Join(text, "\n")) + "</p>\n")
+	return i
+}
+
+// explicit converts an "explicit markup" block: a comment, directive,
+// footnote, citation, hyperlink target, or substitution definition.
+func (p *parser) explicit(lines []string, i int) int {
+	line := lines[i]
+	block, end := indented(lines, i+1)
+
+	if reTarget.MatchString(line) {
+		return end
+	} else if m := reFootnote.FindStringSubmatch(line); m != nil {
+		p.out.WriteString("<div class=\"footnote\">\n")
+		p.body(append([]string{m[2]}, block...))
+		p.out.WriteString("</div>\n")
+		return end
+	} else if m := reDirective.FindStringSubmatch(line); m != nil {
+		p.directive(strings.ToLower(m[1]), m[2], block)
+		return end
+	}
+
+	// A comment, which may contain control statements (e.g., `vale off`).
+	text := strings.TrimSpace(strings.TrimPrefix(line, ".."))
+	if len(block) > 0 {
+		text = strings.TrimSpace(text + "\n" + strings.Join(block, "\n"))
+	}
+	text = strings.Replace(text, "--", "- -", -1)
+	p.out.WriteString("<!-- " + text + " -->\n")
+
+	return end
+}
+
+// indented returns the (dedented) indented block starting at `lines[start]`
+// and the index of the first line after it.
+func indented(lines []string, start int) ([]string, int) {
+	end := start
+	for end < len(lines) && (isBlank(lines[end]) || indentOf(lines[end]) > 0) {
+		end++
+	}
+
+	last := end
+	for last > start && isBlank(lines[last-1]) {
+		last--
+	}
+
+	return dedent(lines[start:last]), end
+}
+
+func dedent(lines []string) []string {
+	min := -1
+	for _, l := range lines {
+		if n := indentOf(l); !isBlank(l) && (min < 0 || n < min) {
+			min = n
+		}
+	}
+
+	block := make([]string, len(lines))
+	for i, l := range lines {
+		if len(l) >= min && min > 0 {
+			block[i] = l[min:]
+		} else {
+			block[i] = strings.TrimLeft(l, " ")
+		}
+	}
+
+	return block
+}
+
+// isEnumerated reports whether the enumerator at `lines[i]` really starts a
+// list item (rather than, e.g., a paragraph starting with "A. Smith").
+func isEnumerated(lines []string, i int) bool {
+	if i+1 >= len(lines) {
+		return true
+	}
+	next := lines[i+1]
+	return isBlank(next) || indentOf(next) > 0 || reEnumerated.MatchString(next)
+}
+
+// isAdornment reports whether `line` is a section title adornment or
+// transition: a run of a single punctuation character.
+func isAdornment(line string) bool {
+	if utf8.RuneCountInString(line) < 2 {
+		return false
+	}
+
+	first, _ := utf8.DecodeRuneInString(line)
+	if first > unicode.MaxASCII || !unicode.IsPunct(first) && !unicode.IsSymbol(first) {
+		return false
+	}
+
+	for _, r := range line {
+		if r != first {
+			return false
+		}
+	}
+	return true
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func splitLines(src string) []string {
+	src = strings.Replace(src, "\r\n", "\n", -1)
+
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		lines[i] = expandIndent(strings.TrimRight(line, " \t\r"))
+	}
+
+	return lines
+}
+
+// expandIndent converts any tabs in the indentation of `line` into spaces
+// (using tab stops of 8, as docutils does).
+func expandIndent(line string) string {
+	width, i := 0, 0
+	for ; i < len(line); i++ {
+		if line[i] == '\t' {
+			width += 8 - width%8
+		} else if line[i] == ' ' {
+			width++
+		} else {
+			break
+		}
+	}
+	return strings.Repeat(" ", width) + line[i:]
+}
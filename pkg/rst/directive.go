@@ -0,0 +1,144 @@
+package rst
+
+import (
+	"html"
+	"strings"
+)
+
+// How a directive's arguments (the text following "::") are treated.
+const (
+	argsContent = iota // they're the first line of the content
+	argsTitle          // they're a title
+	argsVersion        // they're a version, optionally followed by content
+	argsIgnored        // they're not prose (e.g., a class name)
+)
+
+// bodyDirectives are the directives whose content is itself reST.
+var bodyDirectives = map[string]int{
+	"attention": argsContent,
+	"caution":   argsContent,
+	"danger":    argsContent,
+	"error":     argsContent,
+	"hint":      argsContent,
+	"important": argsContent,
+	"note":      argsContent,
+	"seealso":   argsContent,
+	"tip":       argsContent,
+	"todo":      argsContent,
+	"warning":   argsContent,
+
+	"admonition": argsTitle,
+	"centered":   argsTitle,
+	"rubric":     argsTitle,
+	"sidebar":    argsTitle,
+	"table":      argsTitle,
+	"topic":      argsTitle,
+
+	"deprecated":     argsVersion,
+	"versionadded":   argsVersion,
+	"versionchanged": argsVersion,
+
+	"class":      argsIgnored,
+	"compound":   argsIgnored,
+	"container":  argsIgnored,
+	"epigraph":   argsIgnored,
+	"glossary":   argsIgnored,
+	"highlights": argsIgnored,
+	"hlist":      argsIgnored,
+	"only":       argsIgnored,
+	"pull-quote": argsIgnored,
+}
+
+// skippedDirectives produce no prose of their own.
+var skippedDirectives = []string{
+	"codeauthor",
+	"contents",
+	"currentmodule",
+	"default-role",
+	"footer",
+	"header",
+	"highlight",
+	"include",
+	"index",
+	"literalinclude",
+	"meta",
+	"module",
+	"moduleauthor",
+	"role",
+	"sectionauthor",
+	"sectnum",
+	"tabularcolumns",
+	"target-notes",
+	"title",
+	"toctree",
+}
+
+// directive converts the directive `name`.
+//
+// Any directive we don't know about (e.g., `code-block`, `math`, or a custom
+// Sphinx extension) is treated as a literal block.
+func (p *parser) directive(name, args string, block []string) {
+	options, content := splitOptions(block)
+
+	if kind, found := bodyDirectives[name]; found {
+		p.out.WriteString("<div class=\"" + name + "\">\n")
+		switch kind {
+		case argsContent:
+			if args != "" {
+				content = append([]string{args}, content...)
+			}
+		case argsTitle:
+			if args != "" {
+				p.out.WriteString("<p class=\"title\">" + inline(args) + "</p>\n")
+			}
+		case argsVersion:
+			if parts := strings.SplitN(args, " ", 2); len(parts) == 2 {
+				content = append([]string{parts[1]}, content...)
+			}
+		}
+		p.body(content)
+		p.out.WriteString("</div>\n")
+		return
+	}
+
+	switch name {
+	case "image", "figure":
+		p.out.WriteString("<div class=\"figure\">\n")
+		if alt := options["alt"]; alt != "" {
+			p.out.WriteString("<img alt=\"" + html.EscapeString(alt) + "\"/>\n")
+		}
+		if name == "figure" {
+			p.body(content)
+		}
+		p.out.WriteString("</div>\n")
+	case "list-table":
+		p.listTable(options, content)
+	case "csv-table":
+		p.csvTable(options, args, content)
+	default:
+		for _, skipped := range skippedDirectives {
+			if name == skipped {
+				return
+			}
+		}
+		p.literal(content)
+	}
+}
+
+// splitOptions separates a directive's options (e.g., `:alt: text`) from
+// its content.
+func splitOptions(block []string) (map[string]string, []string) {
+	options := make(map[string]string)
+
+	i := 0
+	for ; i < len(block) && reOption.MatchString(block[i]); i++ {
+		parts := strings.SplitN(block[i][1:], ":", 2)
+		options[parts[0]] = strings.TrimSpace(parts[1])
+	}
+
+	for i < len(block) && isBlank(block[i]) {
+		i++
+	}
+
+	return options, block[i:]
+}
@@ -0,0 +1,2 @@
+// Package rst implements a pure-Go converter from reStructuredText to HTML.
+package rst
@@ -0,0 +1,181 @@
+package rst
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var reInline = regexp.MustCompile(`(?s)` + strings.Join([]string{
+	"``(?P<literal>[^\\s`](?:.*?[^\\s])?)``",
+	":(?P<role>\\w[\\w:.+-]*):`(?P<roletext>(?:[^`\\\\]|\\\\.)+)`",
+	"`(?P<ref>(?:[^`\\\\]|\\\\.)+)`__?",
+	"`(?P<interpreted>(?:[^`\\\\]|\\\\.)+)`(?::(?P<suffix>\\w[\\w:.+-]*):)?",
+	`\*\*(?P<strong>[^\s*](?:.*?[^\s\\])?)\*\*`,
+	`\*(?P<emphasis>[^\s*](?:.*?[^\s\\*])?)\*`,
+	`\|(?P<substitution>[^|\s](?:[^|]*[^|\s])?)\|(?:__?)?`,
+	`\[(?P<footnote>#?[\w-]*|\*)\]_`,
+	`(?P<url>(?:https?|ftp)://[^\s<>"]*[^\s<>".,;:!?)\]'])`,
+	`(?P<word>[^\W_](?:[\w.+-]*[^\W_])?)__?`,
+}, "|"))
+
+var reEscape = regexp.MustCompile(`\\(.)`)
+var reTitleTarget = regexp.MustCompile(`(?s)^(.*?)\s*<([^<>]+)>$`)
+
+// proseRoles are rendered as linted text; any other role (e.g., `:code:`,
+// `:py:func:`, or an unknown custom role) is rendered as an inline literal.
+var proseRoles = map[string]string{
+	"abbr":            "abbr",
+	"dfn":             "em",
+	"emphasis":        "em",
+	"guilabel":        "span",
+	"menuselection":   "span",
+	"strong":          "strong",
+	"sub":             "sub",
+	"subscript":       "sub",
+	"sup":             "sup",
+	"superscript":     "sup",
+	"t":               "cite",
+	"title":           "cite",
+	"title-reference": "cite",
+}
+
+// refRoles are cross-references, which are only linted if they have an
+// explicit title (e.g., :ref:`the guide <guide>`).
+var refRoles = []string{"any", "doc", "download", "numref", "ref", "term"}
+
+// inline converts the inline markup in `text` into HTML.
+func inline(text string) string {
+	var b strings.Builder
+
+	names := reInline.SubexpNames()
+	for len(text) > 0 {
+		loc := reInline.FindStringSubmatchIndex(text)
+		if loc == nil {
+			b.WriteString(plain(text))
+			break
+		}
+
+		start, end := loc[0], loc[1]
+		if !startsMarkup(text, start) || !endsMarkup(text, end) {
+			// Not actually markup (e.g., "2*3*4"), so we move past the
+			// start-string.
+			_, size := utf8.DecodeRuneInString(text[start:])
+			b.WriteString(plain(text[:start+size]))
+			text = text[start+size:]
+			continue
+		}
+
+		groups := make(map[string]string)
+		for i, name := range names {
+			if name != "" && loc[2*i] >= 0 {
+				groups[name] = text[loc[2*i]:loc[2*i+1]]
+			}
+		}
+
+		b.WriteString(plain(text[:start]))
+		b.WriteString(markup(groups))
+
+		text = text[end:]
+	}
+
+	return b.String()
+}
+
+func markup(groups map[string]string) string {
+	if s, ok := groups["literal"]; ok {
+		return wrap("code", html.EscapeString(s))
+	} else if role, ok := groups["role"]; ok {
+		return interpreted(role, groups["roletext"])
+	} else if s, ok := groups["ref"]; ok {
+		return reference(s)
+	} else if s, ok := groups["interpreted"]; ok {
+		return interpreted(groups["suffix"], s)
+	} else if s, ok := groups["strong"]; ok {
+		return wrap("strong", plain(s))
+	} else if s, ok := groups["emphasis"]; ok {
+		return wrap("em", plain(s))
+	} else if s, ok := groups["substitution"]; ok {
+		// We don't know the replacement text.
+		return wrap("code", html.EscapeString(s))
+	} else if s, ok := groups["footnote"]; ok {
+		return `<a href="#">[` + html.EscapeString(s) + `]</a>`
+	} else if s, ok := groups["url"]; ok {
+		s = html.EscapeString(s)
+		return `<a href="` + s + `">` + s + `</a>`
+	}
+	return `<a href="#">` + plain(groups["word"]) + `</a>`
+}
+
+// interpreted converts interpreted text with the given role (if any).
+func interpreted(role, text string) string {
+	if idx := strings.LastIndex(role, ":"); idx >= 0 {
+		// A domain-specific role (e.g., `py:func` or `std:ref`).
+		role = role[idx+1:]
+	}
+
+	if role == "" {
+		role = "title-reference"
+	}
+
+	if tag, found := proseRoles[role]; found {
+		return wrap(tag, plain(text))
+	}
+
+	for _, ref := range refRoles {
+		if role != ref {
+			continue
+		}
+		if m := reTitleTarget.FindStringSubmatch(text); m != nil && m[1] != "" {
+			return `<a href="#">` + plain(m[1]) + `</a>`
+		} else if role == "term" {
+			return `<a href="#">` + plain(text) + `</a>`
+		}
+	}
+
+	return wrap("code", html.EscapeString(text))
+}
+
+// reference converts a hyperlink reference, such as `Vale <https://...>`_.
+func reference(text string) string {
+	if m := reTitleTarget.FindStringSubmatch(text); m != nil {
+		target := html.EscapeString(m[2])
+		if m[1] == "" {
+			return `<a href="` + target + `">` + target + `</a>`
+		}
+		return `<a href="` + target + `">` + plain(m[1]) + `</a>`
+	}
+	return `<a href="#">` + plain(text) + `</a>`
+}
+
+// plain escapes `text`, removing any backslash escapes.
+func plain(text string) string {
+	text = strings.Replace(text, "\\ ", "", -1)
+	return html.EscapeString(reEscape.ReplaceAllString(text, "$1"))
+}
+
+func wrap(tag, s string) string {
+	return "<" + tag + ">" + s + "</" + tag + ">"
+}
+
+// startsMarkup reports whether inline markup may start at `text[i]`: it must
+// be at the start of the text or follow whitespace or an opening delimiter.
+func startsMarkup(text string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:i])
+	return unicode.IsSpace(r) || strings.ContainsRune(`'"([{<-/:‘“’«¡¿`, r)
+}
+
+// endsMarkup reports whether inline markup may end at `text[i]`: it must be
+// at the end of the text or be followed by whitespace or punctuation.
+func endsMarkup(text string, i int) bool {
+	if i == len(text) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return unicode.IsSpace(r) || (unicode.IsPunct(r) && r != '_') || r == '>'
+}
@@ -0,0 +1,52 @@
+package rst
+
+import (
+	"strings"
+	"testing"
+)
+
+var htmlTests = []struct {
+	rst      string
+	contains []string
+	excludes []string
+}{
+	{"Title\n=====\n\nSection\n-------\n\nText.", []string{
+		`<h1 data-line="1">Title</h1>`, `<h2 data-line="4">Section</h2>`,
+		`<p data-line="7">Text.</p>`}, nil},
+	{"- One\n- Two\n\n#. Three", []string{
+		`<ul data-line="1">`, "<li><p>One</p>\n</li>", `<ol data-line="4">`, "Three"}, nil},
+	{"+-----+-----+\n| A   | B   |\n+=====+=====+\n| 1   | 2   |\n+-----+-----+", []string{
+		"<th><p>A</p>\n</th>", "<td><p>2</p>\n</td>"}, nil},
+	{"=====  =====\nA      B\n=====  =====\n1      2\n=====  =====", []string{
+		"<th><p>B</p>\n</th>", "<td><p>1</p>\n</td>"}, nil},
+	{"Example::\n\n    x = 1\n\nAfter.", []string{
+		`<p data-line="1">Example:</p>`, "<pre>x = 1</pre>", `<p data-line="5">After.</p>`}, nil},
+	{"Use ``x * y`` and :ref:`the guide <guide>` or :py:func:`len`.", []string{
+		"<code>x * y</code>", `<a href="#">the guide</a>`, "<code>len</code>"}, nil},
+	{"See `Vale <https://vale.sh>`_ and *this* **that**.", []string{
+		`<a href="https://vale.sh">Vale</a>`, "<em>this</em>", "<strong>that</strong>"}, nil},
+	{".. note:: This is\n   a note.\n\n.. code-block:: python\n\n   print('TODO')", []string{
+		"<div data-line=\"1\" class=\"note\">\n<p>This is\na note.</p>",
+		"<pre data-line=\"4\">print(&#39;TODO&#39;)</pre>"}, nil},
+	{".. vale off\n\nText.\n\n.. vale on", []string{
+		"<!-- vale off -->", "<!-- vale on -->"}, nil},
+	{".. _target:\n\n.. toctree::\n   :maxdepth: 2\n\n   intro", nil, []string{
+		"target", "intro", "maxdepth"}},
+	{"2*3*4 is not emphasis.", []string{`<p data-line="1">2*3*4 is not emphasis.</p>`}, nil},
+}
+
+func TestToHTML(t *testing.T) {
+	for _, tt := range htmlTests {
+		out := ToHTML(tt.rst)
+		for _, s := range tt.contains {
+			if !strings.Contains(out, s) {
+				t.Errorf("%q: expected %q in:\n%s", tt.rst, s, out)
+			}
+		}
+		for _, s := range tt.excludes {
+			if strings.Contains(out, s) {
+				t.Errorf("%q: unexpected %q in:\n%s", tt.rst, s, out)
+			}
+		}
+	}
+}
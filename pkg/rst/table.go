@@ -0,0 +1,254 @@
+package rst
+
+import (
+	"encoding/csv"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// row writes a table row whose cells are reST.
+func (p *parser) row(cells [][]string, header bool) {
+	if len(cells) == 0 {
+		return
+	}
+
+	tag := "td"
+	if header {
+		tag = "th"
+	}
+
+	p.out.WriteString("<tr>")
+	for _, cell := range cells {
+		p.out.WriteString("<" + tag + ">")
+		p.body(dedent(cell))
+		p.out.WriteString("</" + tag + ">")
+	}
+	p.out.WriteString("</tr>\n")
+}
+
+// gridTable converts a table of the form
+//
+//	+-------+-------+
+//	| A     | B     |
+//	+=======+=======+
+//	| 1     | 2     |
+//	+-------+-------+
+//
+// A cell that spans multiple rows merges those rows into one.
+func (p *parser) gridTable(lines []string, i int) int {
+	end := i
+	for end < len(lines) && (strings.HasPrefix(lines[end], "+") ||
+		strings.HasPrefix(lines[end], "|")) {
+		end++
+	}
+
+	table := lines[i:end]
+
+	header := false
+	for _, line := range table {
+		if reGridBorder.MatchString(line) && strings.Contains(line, "=") {
+			header = true
+		}
+	}
+
+	// The column boundaries are given by the first border.
+	bounds := []int{}
+	for j, r := range []rune(table[0]) {
+		if r == '+' {
+			bounds = append(bounds, j)
+		}
+	}
+
+	p.out.WriteString("<table>\n")
+
+	cells := make(map[int][]string)
+	for _, line := range table[1:] {
+		if reGridBorder.MatchString(line) {
+			p.row(sortedCells(cells), header)
+			if strings.Contains(line, "=") {
+				header = false
+			}
+			cells = make(map[int][]string)
+			continue
+		}
+
+		// NOTE: A line that's only partially a border (because of a cell
+		// that spans rows) has '+' boundaries.
+		runes := []rune(line)
+		start := bounds[0]
+		for _, b := range bounds[1:] {
+			if b < len(runes) && (runes[b] == '|' || runes[b] == '+') {
+				cell := trimCell(runes[start+1 : b])
+				if strings.Trim(cell, "-=") != "" || cell == "" {
+					cells[start] = append(cells[start], cell)
+				}
+				start = b
+			}
+		}
+	}
+
+	p.out.WriteString("</table>\n")
+	return end
+}
+
+// simpleTable converts a table of the form
+//
+//	=====  =====
+//	A      B
+//	=====  =====
+//	1      2
+//	=====  =====
+func (p *parser) simpleTable(lines []string, i int) int {
+	type column struct{ start, end int }
+
+	columns := []column{}
+	runes := []rune(lines[i])
+	for j := 0; j < len(runes); j++ {
+		if runes[j] == '=' && (j == 0 || runes[j-1] == ' ') {
+			columns = append(columns, column{start: j})
+		}
+		if runes[j] == '=' && (j+1 == len(runes) || runes[j+1] == ' ') {
+			columns[len(columns)-1].end = j + 1
+		}
+	}
+
+	end := i + 1
+	rows := [][][]string{}
+	borders := []int{}
+	for end < len(lines) {
+		line := lines[end]
+		end++
+
+		if reSimpleBorder.MatchString(line) {
+			borders = append(borders, len(rows))
+			if end == len(lines) || isBlank(lines[end]) {
+				break
+			}
+			continue
+		} else if isBlank(line) {
+			continue
+		}
+
+		runes := []rune(line)
+		cells := make([][]string, len(columns))
+		for k, col := range columns {
+			stop := col.end
+			if k+1 == len(columns) || stop > len(runes) {
+				stop = len(runes)
+			}
+			if col.start < stop {
+				cells[k] = []string{trimCell(runes[col.start:stop])}
+			} else {
+				cells[k] = []string{""}
+			}
+		}
+
+		if len(rows) > 0 && strings.TrimSpace(cells[0][0]) == "" {
+			// A continuation of the previous row.
+			prev := rows[len(rows)-1]
+			for k := range cells {
+				prev[k] = append(prev[k], cells[k]...)
+			}
+			continue
+		}
+		rows = append(rows, cells)
+	}
+
+	// With three (or more) borders, the rows above the second are headers.
+	headers := 0
+	if len(borders) > 1 && borders[0] < len(rows) {
+		headers = borders[0]
+	}
+
+	p.out.WriteString("<table>\n")
+	for k, cells := range rows {
+		p.row(cells, k < headers)
+	}
+	p.out.WriteString("</table>\n")
+
+	return end
+}
+
+// listTable converts a `list-table` directive's content (a two-level bullet
+// list) into a table.
+func (p *parser) listTable(options map[string]string, content []string) {
+	headers, _ := strconv.Atoi(options["header-rows"])
+
+	p.out.WriteString("<table>\n")
+	for k, row := range bulletItems(content) {
+		p.row(bulletItems(row), k < headers)
+	}
+	p.out.WriteString("</table>\n")
+}
+
+// csvTable converts a `csv-table` directive's content into a table.
+func (p *parser) csvTable(options map[string]string, title string, content []string) {
+	rows := [][]string{}
+	if h := options["header"]; h != "" {
+		rows = append(rows, readCSV(h)...)
+	}
+	headers := len(rows)
+	if n, err := strconv.Atoi(options["header-rows"]); err == nil {
+		headers += n
+	}
+	rows = append(rows, readCSV(strings.Join(content, "\n"))...)
+
+	if title != "" {
+		p.out.WriteString("<p class=\"title\">" + inline(title) + "</p>\n")
+	}
+
+	p.out.WriteString("<table>\n")
+	for k, row := range rows {
+		cells := make([][]string, len(row))
+		for j, cell := range row {
+			cells[j] = strings.Split(cell, "\n")
+		}
+		p.row(cells, k < headers)
+	}
+	p.out.WriteString("</table>\n")
+}
+
+// bulletItems returns the (dedented) content of each item in a bullet list.
+func bulletItems(lines []string) [][]string {
+	items := [][]string{}
+	for i := 0; i < len(lines); {
+		m := reBullet.FindString(lines[i])
+		if m == "" {
+			i++
+			continue
+		}
+		block, end := indented(lines, i+1)
+		items = append(items, append([]string{lines[i][len(m):]}, block...))
+		i = end
+	}
+	return items
+}
+
+func readCSV(text string) [][]string {
+	r := csv.NewReader(strings.NewReader(text))
+	r.LazyQuotes = true
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	rows, _ := r.ReadAll()
+	return rows
+}
+
+func sortedCells(cells map[int][]string) [][]string {
+	keys := []int{}
+	for k := range cells {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	sorted := [][]string{}
+	for _, k := range keys {
+		sorted = append(sorted, cells[k])
+	}
+	return sorted
+}
+
+func trimCell(runes []rune) string {
+	return strings.TrimRight(string(runes), " ")
+}
@@ -0,0 +1,3 @@
+// Package xmlscope converts XML documents into HTML by mapping element paths
+// to Vale scopes.
+package xmlscope
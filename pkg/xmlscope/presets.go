@@ -0,0 +1,169 @@
+package xmlscope
+
+// Presets are the built-in mappings for common XML vocabularies.
+//
+// "none" is an empty preset, which can be used to opt out of a format's
+// default preset.
+var Presets = map[string][]string{
+	"docbook": docbook,
+	"dita":    dita,
+	"none":    {},
+}
+
+var docbook = []string{
+	"title:heading.h2",
+	"subtitle:heading.h2",
+	"titleabbrev:skip",
+	"/*/title:heading.h1",
+	"/*/info/title:heading.h1",
+	"/*/subtitle:heading.h1",
+	"/*/info/subtitle:heading.h1",
+	"section/section/title:heading.h3",
+	"section/section/info/title:heading.h3",
+	"section/section/section/title:heading.h4",
+	"section/section/section/info/title:heading.h4",
+	"sect2/title:heading.h3",
+	"sect3/title:heading.h4",
+	"sect4/title:heading.h5",
+	"sect5/title:heading.h6",
+
+	"para:paragraph",
+	"simpara:paragraph",
+	"formalpara/title:strong",
+	"listitem:list",
+	"member:list",
+	"step:list",
+	"term:text",
+	"entry:table.cell",
+	"thead/row/entry:table.header",
+	"td:table.cell",
+	"th:table.header",
+	"blockquote:blockquote",
+	"epigraph:blockquote",
+	"footnote:text",
+
+	"emphasis:emphasis",
+	"citetitle:emphasis",
+	"firstterm:emphasis",
+	"glossterm:emphasis",
+	"link:link",
+	"olink:link",
+	"ulink:link",
+
+	"classname:code",
+	"code:code",
+	"command:code",
+	"computeroutput:code",
+	"constant:code",
+	"envar:code",
+	"filename:code",
+	"function:code",
+	"keycap:code",
+	"literal:code",
+	"methodname:code",
+	"option:code",
+	"parameter:code",
+	"prompt:code",
+	"property:code",
+	"replaceable:code",
+	"systemitem:code",
+	"tag:code",
+	"type:code",
+	"uri:code",
+	"userinput:code",
+	"varname:code",
+
+	"address:skip",
+	"classsynopsis:skip",
+	"cmdsynopsis:skip",
+	"equation:skip",
+	"funcsynopsis:skip",
+	"informalequation:skip",
+	"inlineequation:skip",
+	"literallayout:skip",
+	"programlisting:skip",
+	"screen:skip",
+	"synopsis:skip",
+
+	"author:skip",
+	"authorgroup:skip",
+	"biblioid:skip",
+	"copyright:skip",
+	"date:skip",
+	"edition:skip",
+	"editor:skip",
+	"indexterm:skip",
+	"keywordset:skip",
+	"othercredit:skip",
+	"productnumber:skip",
+	"pubdate:skip",
+	"publisher:skip",
+	"releaseinfo:skip",
+	"remark:skip",
+	"revhistory:skip",
+	"subjectset:skip",
+}
+
+var dita = []string{
+	"title:heading.h2",
+	"/*/title:heading.h1",
+	"/*/*/title:heading.h2",
+	"section/title:heading.h3",
+	"example/title:heading.h3",
+	"fig/title:strong",
+	"table/title:strong",
+	"glossentry/glossterm:heading.h1",
+
+	"shortdesc:paragraph",
+	"p:paragraph",
+	"note:text",
+	"cmd:paragraph",
+	"stepresult:text",
+	"li:list",
+	"sli:list",
+	"step:list",
+	"substep:list",
+	"dt:text",
+	"dd:text",
+	"entry:table.cell",
+	"thead/row/entry:table.header",
+	"stentry:table.cell",
+	"sthead/stentry:table.header",
+	"lq:blockquote",
+	"fn:text",
+
+	"b:strong",
+	"i:emphasis",
+	"term:emphasis",
+	"xref:link",
+	"link:link",
+
+	"apiname:code",
+	"cmdname:code",
+	"codeph:code",
+	"filepath:code",
+	"kwd:code",
+	"msgph:code",
+	"option:code",
+	"parmname:code",
+	"synph:code",
+	"systemoutput:code",
+	"tt:code",
+	"userinput:code",
+	"varname:code",
+
+	"codeblock:skip",
+	"draft-comment:skip",
+	"foreign:skip",
+	"indexterm:skip",
+	"lines:skip",
+	"msgblock:skip",
+	"object:skip",
+	"pre:skip",
+	"prolog:skip",
+	"required-cleanup:skip",
+	"screen:skip",
+	"syntaxdiagram:skip",
+	"titlealts:skip",
+	"unknown:skip",
+}
@@ -0,0 +1,245 @@
+package xmlscope
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// scopeToTag maps each supported scope to the HTML tag that represents it.
+//
+// NOTE: `skip` and `inline` are special: a skipped element (and all of its
+// content) is removed and an inline element is transparent.
+var scopeToTag = map[string]string{
+	"blockquote":   "blockquote",
+	"code":         "code",
+	"emphasis":     "em",
+	"heading":      "h2",
+	"heading.h1":   "h1",
+	"heading.h2":   "h2",
+	"heading.h3":   "h3",
+	"heading.h4":   "h4",
+	"heading.h5":   "h5",
+	"heading.h6":   "h6",
+	"inline":       "",
+	"link":         "a",
+	"list":         "li",
+	"paragraph":    "p",
+	"skip":         "",
+	"strong":       "strong",
+	"table.cell":   "td",
+	"table.header": "th",
+	"text":         "p",
+}
+
+// inlineTags are the tags that don't start a new block.
+var inlineTags = []string{"a", "code", "em", "strong"}
+
+// openTag is an HTML tag that we've written for an XML element.
+type openTag struct {
+	name  string
+	block bool // whether it's a mapped block (e.g., a paragraph)
+}
+
+type mapping struct {
+	path     []string
+	anchored bool
+	scope    string
+}
+
+// A Mapper converts XML into HTML according to its mappings.
+type Mapper struct {
+	mappings []mapping
+}
+
+// New creates a Mapper from a list of mappings and preset names (e.g.,
+// "docbook"), which are applied in order.
+//
+// A mapping has the form `path:scope`, where `path` is a "/"-separated list
+// of element names (optionally anchored to the root with a leading "/", and
+// with "*" matching any one element) that's matched against the end of an
+// element's ancestry -- e.g., `section/title:heading.h2`. When more than one
+// mapping matches an element, the longest one wins (with later mappings
+// winning ties).
+func New(entries []string) (*Mapper, error) {
+	m := Mapper{}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		} else if preset, found := Presets[strings.ToLower(entry)]; found {
+			if err := m.add(preset); err != nil {
+				return nil, err
+			}
+		} else if err := m.add([]string{entry}); err != nil {
+			return nil, err
+		}
+	}
+	return &m, nil
+}
+
+func (m *Mapper) add(entries []string) error {
+	for _, entry := range entries {
+		idx := strings.LastIndex(entry, ":")
+		if idx < 0 {
+			return fmt.Errorf("'%s' is neither a preset nor a 'path:scope' mapping", entry)
+		}
+
+		path := strings.TrimSpace(entry[:idx])
+		scope := strings.TrimSpace(entry[idx+1:])
+		if _, found := scopeToTag[scope]; !found {
+			return fmt.Errorf("'%s' isn't a supported XML scope", scope)
+		} else if strings.Trim(path, "/") == "" {
+			return fmt.Errorf("'%s' doesn't have a path", entry)
+		}
+
+		m.mappings = append(m.mappings, mapping{
+			path:     strings.Split(strings.Trim(path, "/"), "/"),
+			anchored: strings.HasPrefix(path, "/"),
+			scope:    scope,
+		})
+	}
+	return nil
+}
+
+// Scope returns the scope assigned to the last element in `stack` (or "" if
+// there's no matching mapping).
+func (m *Mapper) Scope(stack []string) string {
+	scope, longest := "", 0
+	for _, mp := range m.mappings {
+		if len(mp.path) >= longest && mp.matches(stack) {
+			scope, longest = mp.scope, len(mp.path)
+		}
+	}
+	return scope
+}
+
+func (mp mapping) matches(stack []string) bool {
+	if len(mp.path) > len(stack) || (mp.anchored && len(mp.path) != len(stack)) {
+		return false
+	}
+
+	offset := len(stack) - len(mp.path)
+	for i, name := range mp.path {
+		if name != "*" && name != stack[offset+i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ToHTML converts the XML document `src` into HTML.
+//
+// Elements that don't match any mapping are treated as inline markup inside
+// of a mapped block and as generic blocks elsewhere. The opening tag of each block records the element's (1-based) line in
+// `src` as a `data-line` attribute.
+func (m *Mapper) ToHTML(src []byte) (string, error) {
+	var out bytes.Buffer
+
+	d := xml.NewDecoder(bytes.NewReader(src))
+	d.Strict = false
+	d.Entity = xml.HTMLEntity
+
+	stack := []string{}
+	tags := []openTag{}
+
+	blocks := 0 // the number of open (mapped, non-inline) block tags
+	skip := 0   // the depth of the skipped element we're in (if any)
+
+	line, pos := 1, 0 // the (1-based) line of the current token's offset
+	for {
+		start := d.InputOffset()
+		line += bytes.Count(src[pos:start], []byte("\n"))
+		pos = int(start)
+
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return "", err
+		}
+		raw := string(src[start:d.InputOffset()])
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+			if skip > 0 {
+				continue
+			}
+
+			scope := m.Scope(stack)
+			tag, found := scopeToTag[scope]
+			if scope == "skip" {
+				skip = len(stack)
+				continue
+			} else if !found && blocks == 0 {
+				tag = "div"
+			}
+
+			block := found && tag != "" && !isInline(tag)
+			if block {
+				blocks++
+			}
+
+			tags = append(tags, openTag{name: tag, block: block})
+			if tag != "" && isInline(tag) {
+				out.WriteString("<" + tag + ">")
+			} else if tag != "" {
+				out.WriteString(fmt.Sprintf(`<%s data-line="%d">`, tag, line))
+			}
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return "", fmt.Errorf("unexpected end element </%s>", t.Name.Local)
+			}
+
+			stack = stack[:len(stack)-1]
+			if skip > 0 {
+				if len(stack) < skip {
+					skip = 0
+				}
+				continue
+			}
+
+			tag := tags[len(tags)-1]
+			tags = tags[:len(tags)-1]
+			if tag.block {
+				blocks--
+			}
+
+			if tag.name != "" && isInline(tag.name) {
+				out.WriteString("</" + tag.name + ">")
+			} else if tag.name != "" {
+				out.WriteString("</" + tag.name + ">\n")
+			}
+		case xml.CharData:
+			if skip > 0 {
+				continue
+			} else if strings.HasPrefix(raw, "<![CDATA[") {
+				out.WriteString(html.EscapeString(string(t)))
+			} else {
+				// NOTE: We use the raw text (rather than the decoded text)
+				// so that it matches the source document.
+				out.WriteString(raw)
+			}
+		case xml.Comment:
+			if skip == 0 {
+				out.WriteString(raw)
+			}
+		}
+	}
+
+	return out.String(), nil
+}
+
+func isInline(tag string) bool {
+	for _, t := range inlineTags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
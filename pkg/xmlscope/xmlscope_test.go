@@ -0,0 +1,70 @@
+package xmlscope
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScope(t *testing.T) {
+	m, err := New([]string{"title:heading", "section/title:heading.h3", "/doc/title:heading.h1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"doc/title":                "heading.h1",
+		"doc/section/title":        "heading.h3",
+		"doc/section/figure/title": "heading",
+		"doc/section":              "",
+	}
+	for path, expected := range tests {
+		if scope := m.Scope(strings.Split(path, "/")); scope != expected {
+			t.Errorf("%s: expected '%s', got '%s'", path, expected, scope)
+		}
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	for _, entry := range []string{"title:headline", "docbok", ":text"} {
+		if _, err := New([]string{entry}); err == nil {
+			t.Errorf("expected an error for '%s'", entry)
+		}
+	}
+}
+
+func TestToHTML(t *testing.T) {
+	m, err := New([]string{"dita"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := `<?xml version="1.0"?>
+<topic id="t">
+  <title>Install &amp; run</title>
+  <body>
+    <p>Use <codeph>vale</codeph> or <b>see</b> the <i>guide</i>.</p>
+    <!-- vale off -->
+    <codeblock>TODO</codeblock>
+    <note><![CDATA[A <note>.]]></note>
+  </body>
+</topic>`
+
+	out, err := m.ToHTML([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{
+		`<h1 data-line="3">Install &amp; run</h1>`,
+		`<p data-line="5">Use <code>vale</code> or <strong>see</strong> the <em>guide</em>.</p>`,
+		"<!-- vale off -->",
+		`<p data-line="8">A &lt;note&gt;.</p>`} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in:\n%s", s, out)
+		}
+	}
+
+	if strings.Contains(out, "TODO") {
+		t.Errorf("unexpected skipped content in:\n%s", out)
+	}
+}